@@ -0,0 +1,55 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+// matchGlob reports whether name matches pattern. Patterns use path.Match syntax
+// per segment, plus "**" to match any number of directories. A pattern without a
+// slash matches the file's base name anywhere in the tree, like .gitignore.
+func matchGlob(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// matchAlertPaths returns the files that match any of the alert patterns.
+func matchAlertPaths(patterns, files []string) []string {
+	if len(patterns) == 0 {
+		return nil
+	}
+	var matched []string
+	for _, f := range files {
+		for _, p := range patterns {
+			if matchGlob(p, f) {
+				matched = append(matched, f)
+				break
+			}
+		}
+	}
+	return matched
+}
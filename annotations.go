@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// loadAnnotations reads a YAML or JSON map of commit hash (full or abbreviated)
+// to note.
+func loadAnnotations(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotations file: %v", err)
+	}
+	notes := make(map[string]string)
+	if err := yaml.Unmarshal(data, &notes); err != nil {
+		return nil, fmt.Errorf("failed to parse annotations file: %v", err)
+	}
+	return notes, nil
+}
+
+// annotation returns the note for a commit. Keys may be abbreviated hashes;
+// notes for commits that are not loaded are simply never shown. When several
+// keys match, the longest wins, so the choice doesn't depend on map order.
+func (m *Model) annotation(hash string) string {
+	if note, ok := m.config.Annotations[hash]; ok {
+		return note
+	}
+	best, note := "", ""
+	for key, n := range m.config.Annotations {
+		if len(key) < 4 || !strings.HasPrefix(hash, strings.ToLower(key)) {
+			continue
+		}
+		// Keys of the same length can only differ in case.
+		if len(key) > len(best) || (len(key) == len(best) && key < best) {
+			best, note = key, n
+		}
+	}
+	return note
+}
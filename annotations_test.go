@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestAnnotationPrefixes(t *testing.T) {
+	const hash = "abcdef0123456789abcdef0123456789abcdef01"
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{
+		{"full hash", map[string]string{hash: "full", "abcd": "short"}, "full"},
+		{"longest prefix wins", map[string]string{"abcd": "short", "abcdef01": "longer", "abcdef": "middle"}, "longer"},
+		{"case-only duplicates", map[string]string{"ABCDEF": "upper", "abcdef": "lower"}, "upper"},
+		{"too short to match", map[string]string{"abc": "short"}, ""},
+		{"other commit", map[string]string{"abce": "other"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Model{config: Config{Annotations: tt.annotations}}
+			// Map order varies between runs; the answer must not.
+			for range 20 {
+				if got := m.annotation(hash); got != tt.want {
+					t.Fatalf("annotation = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
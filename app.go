@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"image/color"
+	"log/slog"
 	"math"
 	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -30,12 +33,37 @@ const (
 
 // commitInfo holds the information for a single commit
 type commitInfo struct {
-	Hash        string    `json:"hash" yaml:"hash"`
-	Message     string    `json:"message" yaml:"message"`
-	Author      string    `json:"author" yaml:"author"`
-	Date        time.Time `json:"date" yaml:"date"`
-	DiffLoaded  bool      `json:"-" yaml:"-"` // Don't export these
-	DiffContent string    `json:"-" yaml:"-"` // To cache the diff
+	Hash        string         `json:"hash" yaml:"hash"`
+	Message     string         `json:"message" yaml:"message"`
+	Author      string         `json:"author" yaml:"author"`
+	Committer   string         `json:"committer,omitempty" yaml:"committer,omitempty"`
+	Date        time.Time      `json:"date" yaml:"date"`
+	Signed      bool           `json:"signed" yaml:"signed"`
+	AlertPaths  []string       `json:"alert_paths,omitempty" yaml:"alert_paths,omitempty"`   // Changed files matching -alert-path
+	DuplicateOf string         `json:"duplicate_of,omitempty" yaml:"duplicate_of,omitempty"` // Earlier commit with the same patch-id
+	DirChurn    map[string]int `json:"dir_churn,omitempty" yaml:"dir_churn,omitempty"`       // Churn per top-level directory
+
+	// Whitespace-only and rename-only lines, left out of the graph with -ignore-noise
+	NoiseAdditions int `json:"noise_additions,omitempty" yaml:"noise_additions,omitempty"`
+	NoiseDeletions int `json:"noise_deletions,omitempty" yaml:"noise_deletions,omitempty"`
+
+	ParentHashes []string `json:"-" yaml:"-"`
+	ParentCount  int      `json:"-" yaml:"-"` // More than one for merge commits
+
+	// Per-file stats, filled by the fetcher or lazily by fileStatsCmd
+	FileStats       []fileStat `json:"-" yaml:"-"`
+	FileStatsLoaded bool       `json:"-" yaml:"-"`
+	DiffLoaded      bool       `json:"-" yaml:"-"` // Don't export these
+	DiffContent     string     `json:"-" yaml:"-"` // To cache the diff
+	DiffLines       []string   `json:"-" yaml:"-"` // DiffContent split into lines, so scrolling doesn't re-split it
+
+	// Paths the commit added, modified and deleted, set by the fetcher for file lifetimes
+	Paths *pathChanges `json:"-" yaml:"-"`
+
+	// Lines of code in the tree with -loc-every: counted on sampled commits,
+	// interpolated in between
+	LOC        int  `json:"loc,omitempty" yaml:"loc,omitempty"`
+	LOCSampled bool `json:"-" yaml:"-"`
 
 	// These are the diff stats for this specific commit
 	Files     int `json:"files" yaml:"files"`
@@ -49,15 +77,102 @@ type commitInfo struct {
 	CumulativeDeletions int `json:"cumulative_deletions" yaml:"cumulative_deletions"`
 }
 
+type fileStat struct {
+	Name      string `json:"name"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
 type authorStat struct {
-	name  string
-	churn int
+	key       string // authorKey of name
+	name      string
+	churn     int
+	commits   int
+	additions int
+	deletions int
+	last      time.Time // Date of the most recent commit
+}
+
+// busyDay identifies one author's commits on one calendar day.
+type busyDay struct {
+	author  string
+	day     string // YYYY-MM-DD in the commit's own time zone
+	commits int
+}
+
+// busyDays returns the author-days with more than threshold commits, busiest
+// first. A threshold of 0 disables the check.
+func busyDays(daily map[busyDay]int, threshold int) []busyDay {
+	if threshold <= 0 {
+		return nil
+	}
+	var busy []busyDay
+	for key, n := range daily {
+		if n > threshold {
+			key.commits = n
+			busy = append(busy, key)
+		}
+	}
+	sort.Slice(busy, func(i, j int) bool {
+		if busy[i].commits != busy[j].commits {
+			return busy[i].commits > busy[j].commits
+		}
+		if busy[i].day != busy[j].day {
+			return busy[i].day < busy[j].day
+		}
+		return busy[i].author < busy[j].author
+	})
+	return busy
+}
+
+// Contributor orderings for the developer stats panel, cycled with s.
+const (
+	sortByChurn = iota
+	sortByCommits
+	sortByAdditions
+	sortByDeletions
+	sortByRecent
+	numContributorSorts
+)
+
+var contributorSortNames = [numContributorSorts]string{"churn", "commits", "additions", "deletions", "last commit"}
+
+// contributorLess orders a before b for the given sort mode, falling back to
+// churn and then name so the list is stable between frames.
+func contributorLess(mode int, a, b authorStat) bool {
+	switch mode {
+	case sortByCommits:
+		if a.commits != b.commits {
+			return a.commits > b.commits
+		}
+	case sortByAdditions:
+		if a.additions != b.additions {
+			return a.additions > b.additions
+		}
+	case sortByDeletions:
+		if a.deletions != b.deletions {
+			return a.deletions > b.deletions
+		}
+	case sortByRecent:
+		if !a.last.Equal(b.last) {
+			return a.last.After(b.last)
+		}
+	}
+	if a.churn != b.churn {
+		return a.churn > b.churn
+	}
+	return a.name < b.name
 }
 
 // Model represents the Bubble Tea application model
 type Model struct {
+	nowFunc            func() time.Time // Clock for time-relative state; time.Now outside tests
 	config             Config
+	keys               keyMap
 	repo               *git.Repository
+	fileStatsRepo      *git.Repository // Handle fileStatsCmd reads commits through
+	fileStatsLoading   bool            // A fileStatsCmd is in flight
+	fileStatsFailed    map[string]bool // Commits whose file stats couldn't be read
 	commits            []*commitInfo
 	currentCommitIndex int
 	width, height      int // Terminal dimensions
@@ -66,26 +181,101 @@ type Model struct {
 	maxAdditions       int
 	maxDeletions       int
 
-	autoProgress     bool
-	progressInterval time.Duration
+	autoProgress      bool
+	progressInterval  time.Duration
+	showGraph         bool // Draw branch/merge lanes in the timeline
+	showDirChart      bool // Show churn by directory in place of the changes graph
+	highlightStyle    lipgloss.Style
+	deletionsOnTop    bool                // Flip the changes graph so deletions grow upwards
+	additionsShare    int                 // Percent of the changes graph height above the zero line for additions
+	graphMode         string              // One of the graphMode* constants
+	contributorSort   int                 // One of the sortBy* constants
+	showLifetimes     bool                // Show the longest-lived files in place of developer stats
+	statsPerCommit    bool                // Show the selected commit's own additions/deletions instead of running totals
+	tourPaused        bool                // Playback stopped on an annotated commit in tour mode
+	byCommitter       bool                // Group contributor stats by committer instead of author
+	showDaySeparators bool                // Date rows between days in the timeline
+	compact           bool                // Narrow terminal: one panel at a time
+	compactPanel      int                 // Index into compactPanels
+	notice            string              // One-off message in the stats panel, cleared on the next key
+	resumeHash        string              // Saved position to jump to once it loads
+	showDiffHash      string              // -show-diff commit to open once it loads
+	debug             *debugStats         // -debug-stats overlay, nil when off
+	statsCache        *statsCache         // Fetcher stats from earlier runs, nil with -no-cache
+	loc               *locCounter         // Tree line counts for -loc-every, nil when off
+	maxLOC            int                 // Largest LOC sample so far, the size graph's scale
+	recorder          *recorder           // -record output, nil when not recording
+	tags              map[string][]string // -tags-glob matches by commit hash
+	subjectRewrites   []subjectRewriter   // Applied to timeline subjects
+	graphCanvas       *BrailleCanvas      // Reused by renderBrailleGraph between frames
+	authorCursorOn    bool                // The contributor list shows a selection cursor
+	authorCursor      int                 // Row of the cursor in the contributor list
+	comparedAuthors   []authorStat        // Authors picked for comparison by key and name, oldest first
+	state             persistedState
+	signatureCache    map[string]signatureInfo // Verified signatures by commit hash
+	lifetimeTracker   lifetimeTracker
+	lifetimesHash     string
+	lifetimes         []fileLifetime
 
 	processedCommitsChan chan *commitInfo
 	loadingComplete      bool
 	program              *tea.Program
 	diffState            diffViewState
 	currentDiff          string
+	currentDiffLines     []string // currentDiff split into lines for rendering
+	currentDiffFor       string   // Commit (or A..B range) currentDiff belongs to
 	diffScroll           int
+	diffContext          int                             // Context lines shown around diff hunks
+	diffCache            map[diffCacheKey]diffCacheEntry // Diffs generated with non-default options
+	diffCacheOrder       []diffCacheKey                  // diffCache keys, least recently used first
+	diffIgnoreWhitespace bool
+	diffNormalizeEOL     bool            // Hide CRs and BOMs in the diff view
+	diffLineEndings      map[int]eolInfo // Files in currentDiffLines with CRLF or a BOM, by header line
+	diffNotice           string          // One-off status shown after pager/export actions
+	diffSearchPrompt     bool            // The / prompt is taking keys
+	splitDiff            bool            // Diff view shows old and new side by side
+	diffFileStarts       []int           // Indices of the file headers in currentDiffLines
+	diffWrap             bool            // Wrap long diff lines instead of leaving them to the terminal
+	showLineNumbers      bool            // Old and new line numbers before each diff line
+	diffWrapTop          diffWrapTop
+	diffSearchInput      string         // Query typed at the prompt
+	diffSearch           *regexp.Regexp // Case-insensitive search, nil when cleared
+	diffIsRange          bool           // currentDiff spans the marked commits
+	diffPairs            map[int]int    // Replaced and replacing lines of currentDiffLines, both ways
+	lcs                  lcsTable       // Scratch table for word diffs
+
+	// Line numbers of currentDiffLines, set by indexDiffLines
+	diffNumbers      []diffLineNumber // Old and new number of each line; 0 where a side has none
+	diffNumberDigits int              // Digits in the largest number, for the gutter width
+
+	// Commits marked for comparison
+	markA, markB   string
+	rangeSummary   *rangeSummary
+	skippedCommits []string // Hashes dropped by the fetcher due to errors
 
 	// State for developer stats view
 	displayedStatsYear   int // 0 for All-Time
 	availableStatYears   []int
 	currentStatYearIndex int
+	statYearCounts       map[int]int // Commits per year up to statYearsThrough
+	statYearsThrough     int
+	devStats             runningDevStats
+
+	// Idle screensaver state
+	lastInput          time.Time
+	idleActive         bool
+	idleStep           time.Time
+	idleSavedIndex     int
+	idleSavedYearIndex int
+	idleSavedAuto      bool
+	ticksStopped       bool // Progress ticks paused while fully idle
 
 	// Report mode progress
 	reportTotal     int
 	reportProcessed int
 	reportWorkers   int
 	reportEngine    string
+	reportETA       etaEstimator
 }
 
 func (m *Model) SetProgram(p *tea.Program) {
@@ -93,10 +283,30 @@ func (m *Model) SetProgram(p *tea.Program) {
 }
 
 func InitialModel(cfg Config) Model {
-	return Model{
+	keys, err := newKeyMap(cfg.Keybindings)
+	if err != nil {
+		slog.Warn("invalid keybindings, using defaults", "err", err)
+		keys, _ = newKeyMap(nil)
+	}
+	subjectRewrites, err := compileSubjectRewrites(cfg.SubjectPresets, cfg.SubjectRewrites)
+	if err != nil {
+		slog.Warn("invalid subject rewrites, showing subjects as they are", "err", err)
+	}
+	m := Model{
+		nowFunc:              time.Now,
 		config:               cfg,
+		keys:                 keys,
 		currentCommitIndex:   0,
 		autoProgress:         cfg.AutoProgress,
+		showGraph:            cfg.ShowGraph,
+		deletionsOnTop:       cfg.DeletionsOnTop,
+		additionsShare:       cfg.AdditionsShare,
+		subjectRewrites:      subjectRewrites,
+		showLineNumbers:      cfg.DiffLineNumbers,
+		graphMode:            cfg.GraphMode,
+		showDaySeparators:    cfg.DaySeparators,
+		state:                loadState(),
+		signatureCache:       make(map[string]signatureInfo),
 		progressInterval:     time.Duration(cfg.ProgressIntervalMs) * time.Millisecond,
 		networkGraphHeight:   0,
 		graphColumns:         0,
@@ -105,17 +315,93 @@ func InitialModel(cfg Config) Model {
 		loadingComplete:      false,
 		processedCommitsChan: make(chan *commitInfo, 100),
 		diffState:            notInDiffView,
+		diffContext:          defaultDiffContext,
+		diffIgnoreWhitespace: cfg.DiffIgnoreWhitespace,
+		diffNormalizeEOL:     cfg.DiffNormalizeEOL,
+		diffCache:            make(map[diffCacheKey]diffCacheEntry),
+		fileStatsFailed:      make(map[string]bool),
 		displayedStatsYear:   0, // Default to All-Time
 		currentStatYearIndex: 0, // Default to All-Time
+		highlightStyle:       newHighlightStyle(cfg.Highlight),
+	}
+	m.lastInput = m.nowFunc()
+	if cfg.Resume {
+		m.resumeHash = m.state.Positions[positionKey(cfg)]
+	}
+	if cfg.ShowDiff != "" {
+		m.resolveShowDiff()
+	}
+	if cfg.DebugStats {
+		m.debug = &debugStats{}
+		m.debug.sample(time.Now())
+	}
+	if cfg.Width > 0 && cfg.Height > 0 {
+		m.resize(cfg.Width, cfg.Height)
+	}
+	return m
+}
+
+// viewMargin is how much narrower and shorter than the terminal resize sets
+// m.width and m.height. Views that use the full terminal width add it back.
+const viewMargin = 10
+
+// resize lays the dashboard out for a terminal of the given size. Configured
+// Width/Height take precedence so output can be reproduced regardless of the
+// actual terminal.
+func (m *Model) resize(width, height int) {
+	if m.config.Width > 0 {
+		width = m.config.Width
+	}
+	if m.config.Height > 0 {
+		height = m.config.Height
+	}
+	m.compact = m.config.CompactWidth > 0 && width < m.config.CompactWidth
+	m.width = width - viewMargin
+	m.height = height - viewMargin
+	m.graphColumns = m.columnWidth() - 10
+	m.networkGraphHeight = m.height/3 - 10
+	if m.diffState == inDiffView && !m.diffIsRange && m.config.MessageWrapWidth <= 0 {
+		m.loadCurrentDiff() // Re-wrap the message for the new width
+	}
+}
+
+func newHighlightStyle(h HighlightConfig) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(h.Bold)
+	if h.Background != "" {
+		style = style.Background(lipgloss.Color(h.Background))
 	}
+	return style
+}
+
+// authorKey groups author names the way the contributor stats do.
+func (m *Model) authorKey(name string) string {
+	return authorKeyFor(name, m.config.NormalizeAuthors)
+}
+
+// shortHash abbreviates a hash for display to hashLength characters, or
+// returns it whole when full hashes are toggled on.
+func (m *Model) shortHash(hash string) string {
+	if m.state.FullHashes || m.config.HashLength <= 0 || m.config.HashLength >= len(hash) {
+		return hash
+	}
+	return hash[:m.config.HashLength]
 }
 
 func (m *Model) Init() tea.Cmd {
+	return tea.Batch(m.start(), m.debugTickCmd(), m.loadTagsCmd())
+}
+
+// start kicks off loading for the configured source.
+func (m *Model) start() tea.Cmd {
 	if m.config.ReportMode {
 		if m.config.ReportPreload {
 			return nil
 		}
-		return m.loadAllCommitsCmd()
+		// The tick keeps the loading ETA counting down between progress updates.
+		return tea.Batch(m.loadAllCommitsCmd(), m.progressTickCmd())
+	}
+	if m.config.FromJSON != "" {
+		return tea.Batch(m.loadFromJSONCmd(), m.progressTickCmd())
 	}
 	go m.fetcher()
 	return m.progressTickCmd()
@@ -123,98 +409,189 @@ func (m *Model) Init() tea.Cmd {
 
 func (m *Model) fetcher() {
 	defer close(m.processedCommitsChan)
+	if m.config.Demo {
+		m.generateDemoCommits()
+		return
+	}
+	start := time.Now()
 
 	r, err := git.PlainOpenWithOptions(m.config.RepoPath, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
 	if err != nil {
+		slog.Error("failed to open repository", "path", m.config.RepoPath, "err", err)
 		if m.program != nil {
 			m.program.Send(errMsg{fmt.Errorf("failed to open repository: %v", err)})
 		}
 		return
 	}
 	m.repo = r
+	slog.Info("opened repository", "path", m.config.RepoPath)
 
-	cmd := exec.Command("git", "-C", m.config.RepoPath, "rev-list", "--reverse", "HEAD")
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
+	commitCount := 0
+	skippedCount := 0
+	skip := func(hash, reason string, err error) {
+		skippedCount++
+		slog.Warn("skipping commit: "+reason, "hash", hash, "err", err)
 		if m.program != nil {
-			m.program.Send(errMsg{fmt.Errorf("failed to create stdout pipe for git rev-list: %v", err)})
+			m.program.Send(commitSkippedMsg{hash: hash})
 		}
-		return
 	}
 
-	if err := cmd.Start(); err != nil {
-		if m.program != nil {
-			m.program.Send(errMsg{fmt.Errorf("failed to start git rev-list: %v", err)})
+	var scanner *bufio.Scanner
+	var cmd *exec.Cmd
+	var reflog []reflogEntry
+	if m.config.Source == sourceReflog || m.config.Source == sourceStash {
+		reflog, err = readReflog(m.config.RepoPath, m.config.Source)
+		if err != nil {
+			slog.Error("failed to read reflog", "source", m.config.Source, "err", err)
+			if m.program != nil {
+				m.program.Send(errMsg{err})
+			}
+			return
 		}
-		return
+		hashes := make([]string, len(reflog))
+		for i, e := range reflog {
+			hashes[i] = e.hash
+		}
+		scanner = bufio.NewScanner(strings.NewReader(strings.Join(hashes, "\n")))
+	} else if m.config.CommitsFile != "" {
+		hashes, unknown, err := readCommitList(m.config.RepoPath, m.config.CommitsFile)
+		if err != nil {
+			slog.Error("failed to load commits file", "path", m.config.CommitsFile, "err", err)
+			if m.program != nil {
+				m.program.Send(errMsg{err})
+			}
+			return
+		}
+		for _, rev := range unknown {
+			skip(rev, "unknown commit in commits file", fmt.Errorf("no such commit"))
+		}
+		scanner = bufio.NewScanner(strings.NewReader(strings.Join(hashes, "\n")))
+	} else {
+		if err := resolveRange(r, m.config); err != nil {
+			slog.Error("failed to resolve range", "err", err)
+			if m.program != nil {
+				m.program.Send(errMsg{err})
+			}
+			return
+		}
+		args := append([]string{"-C", m.config.RepoPath, "rev-list", "--reverse"}, m.config.revListOrder()...)
+		args = append(append(args, m.config.revisionRange()), m.config.pathspec()...)
+		cmd = exec.Command("git", args...)
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			if m.program != nil {
+				m.program.Send(errMsg{fmt.Errorf("failed to create stdout pipe for git rev-list: %v", err)})
+			}
+			return
+		}
+
+		if err := cmd.Start(); err != nil {
+			slog.Error("failed to start git rev-list", "err", err)
+			if m.program != nil {
+				m.program.Send(errMsg{fmt.Errorf("failed to start git rev-list: %v", err)})
+			}
+			return
+		}
+		slog.Debug("started subprocess", "args", cmd.Args, "pid", cmd.Process.Pid)
+		scanner = bufio.NewScanner(stdout)
 	}
 
-	scanner := bufio.NewScanner(stdout)
-	commitCount := 0
+	var dupes *duplicateTracker
+	if m.config.DedupeCommits {
+		if ids, err := computePatchIDs(m.config.RepoPath, m.config.revisionRange()); err != nil {
+			slog.Warn("duplicate detection disabled", "err", err)
+		} else {
+			dupes = newDuplicateTracker(ids)
+		}
+	}
 
-	for scanner.Scan() {
-		hashStr := scanner.Text()
-		hash := plumbing.NewHash(hashStr)
+	if m.config.statsCacheUsable() {
+		m.statsCache = loadStatsCache(m.config.StatsSource)
+		defer m.statsCache.save()
+	}
 
-		commit, err := r.CommitObject(hash)
+	if m.config.LOCEvery > 0 {
+		m.loc = newLOCCounter()
+	}
+
+	// Each worker reads objects through its own repository handle, as go-git
+	// repositories aren't safe for concurrent use and r is shared with the
+	// diff view.
+	workers := m.config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var repos []*git.Repository
+	for len(repos) < workers {
+		wr, err := git.PlainOpenWithOptions(m.config.RepoPath, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
 		if err != nil {
-			continue
+			slog.Warn("failed to open repository for worker", "err", err)
+			break
 		}
+		repos = append(repos, wr)
+	}
+	if len(repos) == 0 {
+		repos = []*git.Repository{r}
+	}
 
-		var filesChanged, additions, deletions, churn int
-		if commit.NumParents() > 0 {
-			parent, err := commit.Parent(0)
-			if err != nil {
-				continue
-			}
-			cTree, err := commit.Tree()
-			if err != nil {
-				continue
-			}
-			pTree, err := parent.Tree()
-			if err != nil {
-				continue
-			}
-			patch, err := pTree.Patch(cTree)
-			if err != nil {
-				continue
-			}
-			stats := patch.Stats()
-			filesChanged = len(stats)
-			for _, s := range stats {
-				additions += s.Addition
-				deletions += s.Deletion
-			}
-			churn = additions + deletions
+	// With -loc-every the newest commit is held back until the fetch stops,
+	// so the last one can always be sampled and the size graph ends on a count
+	// rather than a repeat of the previous sample.
+	var held *commitInfo
+	m.fetchOrdered(scanner, repos, func(res fetchResult) bool {
+		if res.err != nil {
+			skip(res.hash, res.reason, res.err)
+			return true
 		}
-
-		m.processedCommitsChan <- &commitInfo{
-			Hash:      commit.Hash.String(),
-			Message:   commit.Message,
-			Author:    commit.Author.Name,
-			Date:      commit.Author.When,
-			Files:     filesChanged,
-			Additions: additions,
-			Deletions: deletions,
-			Churn:     churn,
+		info := res.info
+		if info == nil {
+			return true
+		}
+		if res.seq < len(reflog) {
+			reflog[res.seq].apply(info)
+		}
+		m.statsCache.add(info)
+		dupes.mark(info)
+		if info.DuplicateOf != "" {
+			// Duplicates don't count as noise; see markNoise.
+			info.NoiseAdditions, info.NoiseDeletions = 0, 0
+		}
+		if m.config.LOCEvery > 0 {
+			info, held = held, info
+		}
+		if info != nil {
+			m.processedCommitsChan <- info
 		}
 		commitCount++
-		if m.config.CommitLimit > 0 && commitCount >= m.config.CommitLimit {
-			break
+		return m.config.CommitLimit <= 0 || commitCount < m.config.CommitLimit
+	})
+	if held != nil {
+		// The workers are done, so their first handle is free again.
+		if !held.LOCSampled {
+			m.sampleLOC(repos[0], held)
 		}
+		m.processedCommitsChan <- held
 	}
 
-	cmd.Wait()
+	if cmd != nil {
+		if err := cmd.Wait(); err != nil {
+			slog.Debug("git rev-list exited", "err", err)
+		}
+	}
+	slog.Info("fetcher finished", "commits", commitCount, "skipped", skippedCount, "elapsed", time.Since(start))
+}
+
+// commitSkippedMsg reports a commit the fetcher dropped because its stats couldn't be computed.
+type commitSkippedMsg struct {
+	hash string
 }
 
 type reportLoadedMsg struct {
-	repo         *git.Repository
-	commits      []*commitInfo
-	maxAdditions int
-	maxDeletions int
-	total        int
-	workers      int
-	engine       string
+	repo    *git.Repository
+	commits []*commitInfo
+	total   int
+	workers int
+	engine  string
 }
 
 type reportProgressMsg struct {
@@ -240,18 +617,16 @@ func (m *Model) loadAllCommitsCmd() tea.Cmd {
 			}
 		}
 		engine := "git-par"
-		repo, commits, maxAdditions, maxDeletions, total, workers, err := loadAllCommitsGitParallel(m.config, makeProgress(engine))
+		repo, commits, total, workers, err := loadAllCommitsGitParallel(m.config, makeProgress(engine))
 		if err != nil {
 			return errMsg{err}
 		}
 		return reportLoadedMsg{
-			repo:         repo,
-			commits:      commits,
-			maxAdditions: maxAdditions,
-			maxDeletions: maxDeletions,
-			total:        total,
-			workers:      workers,
-			engine:       engine,
+			repo:    repo,
+			commits: commits,
+			total:   total,
+			workers: workers,
+			engine:  engine,
 		}
 	}
 }
@@ -261,6 +636,9 @@ type commitStats struct {
 	additions int
 	deletions int
 	churn     int
+	paths     []string // Only collected when requested
+	dirChurn  map[string]int
+	fileStats []fileStat
 }
 
 type reportFile struct {
@@ -270,20 +648,46 @@ type reportFile struct {
 	Commits   []*commitInfo `json:"commits"`
 }
 
-func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, workers int)) (*git.Repository, []*commitInfo, int, int, int, int, error) {
+func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, workers int)) (*git.Repository, []*commitInfo, int, int, error) {
+	start := time.Now()
 	r, err := git.PlainOpenWithOptions(cfg.RepoPath, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
 	if err != nil {
-		return nil, nil, 0, 0, 0, 0, fmt.Errorf("failed to open repository: %v", err)
+		return nil, nil, 0, 0, fmt.Errorf("failed to open repository: %v", err)
+	}
+	slog.Info("opened repository", "path", cfg.RepoPath)
+	if cfg.Source == sourceHistory && cfg.CommitsFile == "" {
+		if err := resolveRange(r, cfg); err != nil {
+			return nil, nil, 0, 0, err
+		}
 	}
 
 	commits, err := loadCommitMetadata(cfg)
 	if err != nil {
-		return nil, nil, 0, 0, 0, 0, err
+		return nil, nil, 0, 0, err
 	}
 	if cfg.ReportSamplePct > 0 {
 		cfg.ReportFilePath = ""
 	}
 
+	if cfg.SignedOnly || cfg.UnsignedOnly {
+		// Reading every commit object is slow on large histories, so signatures
+		// are only looked at when they filter the timeline.
+		filtered := commits[:0]
+		for _, c := range commits {
+			if obj, err := r.CommitObject(plumbing.NewHash(c.Hash)); err == nil {
+				c.Signed = obj.PGPSignature != ""
+			}
+			if cfg.signatureFilterAllows(c.Signed) {
+				filtered = append(filtered, c)
+			}
+		}
+		commits = filtered
+	}
+	if cfg.SignedOnly || cfg.UnsignedOnly || len(cfg.AlertPaths) > 0 || cfg.DedupeCommits || cfg.CommitsFile != "" || cfg.ChurnMode != churnSum || cfg.IgnoreNoise || cfg.Branch != "" || cfg.RangeFrom != "" || cfg.RangeTo != "" || len(cfg.PathFilter) > 0 {
+		// A saved report may have been built with different filters.
+		cfg.ReportFilePath = ""
+	}
+
 	var cached []*commitInfo
 	cacheIndex := -1
 	if cfg.ReportFilePath != "" {
@@ -311,6 +715,16 @@ func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, worke
 	}
 
 	if len(cached) > 0 {
+		// Parents are not saved in the report file; take them from the metadata.
+		meta := make(map[string]*commitInfo, startIndex)
+		for _, c := range commits[:startIndex] {
+			meta[c.Hash] = c
+		}
+		for _, c := range cached {
+			if mc, ok := meta[c.Hash]; ok {
+				c.ParentHashes, c.ParentCount = mc.ParentHashes, mc.ParentCount
+			}
+		}
 		commits = append(append([]*commitInfo{}, cached...), commits[startIndex:]...)
 	} else {
 		commits = commits[startIndex:]
@@ -318,7 +732,7 @@ func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, worke
 
 	total := len(commits) - len(cached)
 	if total <= 0 {
-		return r, commits, maxAdditions(commits), maxDeletions(commits), 0, 0, nil
+		return r, commits, 0, 0, nil
 	}
 
 	workerCount := cfg.ReportWorkers
@@ -374,7 +788,7 @@ func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, worke
 		}
 
 		go func(hs []string) {
-			stats, err := runGitNumstat(cfg.RepoPath, hs, func() {
+			stats, err := runGitNumstat(cfg.RepoPath, hs, len(cfg.AlertPaths) > 0, func() {
 				newCount := atomic.AddInt64(&processed, 1)
 				if progress != nil && progressStep > 0 && int(newCount)%progressStep == 0 {
 					progress(int(newCount), total, workerCount)
@@ -387,23 +801,49 @@ func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, worke
 	for i := 0; i < workerCount && i*chunkSize < total; i++ {
 		res := <-results
 		if res.err != nil {
-			return nil, nil, 0, 0, 0, 0, res.err
+			return nil, nil, 0, 0, res.err
 		}
 		for hash, stat := range res.stats {
 			statsByHash[hash] = stat
 		}
 	}
 
-	maxAdditions := 0
-	maxDeletions := 0
+	if len(cfg.PathFilter) > 0 {
+		kept := commits[:0]
+		for _, c := range commits {
+			stat := filterCommitStats(cfg.PathFilter, statsByHash[c.Hash])
+			if stat.files == 0 {
+				continue
+			}
+			statsByHash[c.Hash] = stat
+			kept = append(kept, c)
+		}
+		commits = kept
+	}
+
+	var dupes *duplicateTracker
+	if cfg.DedupeCommits {
+		ids, err := computePatchIDs(cfg.RepoPath, cfg.revisionRange())
+		if err != nil {
+			return nil, nil, 0, 0, err
+		}
+		dupes = newDuplicateTracker(ids)
+	}
+
 	for i := 0; i < len(commits); i++ {
 		stat, ok := statsByHash[commits[i].Hash]
 		if ok {
 			commits[i].Files = stat.files
 			commits[i].Additions = stat.additions
 			commits[i].Deletions = stat.deletions
-			commits[i].Churn = stat.churn
+			commits[i].Churn = computeChurn(cfg.ChurnMode, stat.additions, stat.deletions)
+			commits[i].AlertPaths = matchAlertPaths(cfg.AlertPaths, stat.paths)
+			commits[i].DirChurn = stat.dirChurn
+			commits[i].FileStats = stat.fileStats
+			commits[i].FileStatsLoaded = true
 		}
+		dupes.mark(commits[i])
+		markNoise(cfg, commits[i])
 
 		if i > 0 {
 			prev := commits[i-1]
@@ -415,13 +855,6 @@ func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, worke
 			commits[i].CumulativeAdditions = commits[i].Additions
 			commits[i].CumulativeDeletions = commits[i].Deletions
 		}
-
-		if commits[i].Additions > maxAdditions {
-			maxAdditions = commits[i].Additions
-		}
-		if commits[i].Deletions > maxDeletions {
-			maxDeletions = commits[i].Deletions
-		}
 	}
 
 	if progress != nil {
@@ -429,30 +862,27 @@ func loadAllCommitsGitParallel(cfg Config, progress func(processed, total, worke
 	}
 
 	if cfg.ReportFilePath != "" && cfg.ReportSamplePct == 0 {
-		_ = saveReportFile(cfg.ReportFilePath, cfg.RepoPath, commits)
-	}
-
-	return r, commits, maxAdditions, maxDeletions, total, workerCount, nil
-}
-
-func maxAdditions(commits []*commitInfo) int {
-	maxVal := 0
-	for _, c := range commits {
-		if c.Additions > maxVal {
-			maxVal = c.Additions
+		if err := saveReportFile(cfg.ReportFilePath, cfg.RepoPath, commits); err != nil {
+			slog.Warn("failed to save report file", "path", cfg.ReportFilePath, "err", err)
 		}
 	}
-	return maxVal
+	slog.Info("report load finished", "commits", len(commits), "computed", total, "workers", workerCount, "elapsed", time.Since(start))
+
+	return r, commits, total, workerCount, nil
 }
 
-func maxDeletions(commits []*commitInfo) int {
-	maxVal := 0
-	for _, c := range commits {
-		if c.Deletions > maxVal {
-			maxVal = c.Deletions
+// recomputeMaxima rescans the loaded commits for the graph scale. Call it
+// whenever m.commits is replaced or filtered rather than appended to.
+func (m *Model) recomputeMaxima() {
+	m.maxAdditions, m.maxDeletions = 0, 0
+	for _, c := range m.commits {
+		if !m.scalesCommit(c) {
+			continue
 		}
+		additions, deletions := graphStats(c)
+		m.maxAdditions = max(m.maxAdditions, additions)
+		m.maxDeletions = max(m.maxDeletions, deletions)
 	}
-	return maxVal
 }
 
 func loadReportFile(path string) ([]*commitInfo, string, error) {
@@ -486,19 +916,74 @@ func saveReportFile(path, repoPath string, commits []*commitInfo) error {
 }
 
 func loadCommitMetadata(cfg Config) ([]*commitInfo, error) {
-	format := "%H%x1f%an%x1f%ad%x1f%s"
+	format := "%H%x1f%an%x1f%ad%x1f%P%x1f%cn%x1f%s"
 	args := []string{
 		"-C", cfg.RepoPath,
 		"log",
-		"--reverse",
 		"--date=iso-strict",
 		"--pretty=format:" + format,
 	}
+	var listed []string
+	var reflog []reflogEntry
+	if cfg.Source == sourceReflog || cfg.Source == sourceStash {
+		entries, err := readReflog(cfg.RepoPath, cfg.Source)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		var revs []string
+		for _, e := range entries {
+			if !seen[e.hash] {
+				seen[e.hash] = true
+				revs = append(revs, e.hash)
+			}
+		}
+		hashes, unknown, err := resolveCommits(cfg.RepoPath, revs)
+		if err != nil {
+			return nil, err
+		}
+		missing := make(map[string]bool)
+		for _, h := range unknown {
+			slog.Warn("skipping reflog entry: commit is not readable", "hash", h)
+			missing[h] = true
+		}
+		for _, e := range entries {
+			if !missing[e.hash] {
+				reflog = append(reflog, e)
+			}
+		}
+		if len(hashes) == 0 {
+			return nil, nil
+		}
+		listed = hashes
+		args = append(args, "--no-walk=unsorted", "--stdin")
+	} else if cfg.CommitsFile != "" {
+		hashes, unknown, err := readCommitList(cfg.RepoPath, cfg.CommitsFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, rev := range unknown {
+			slog.Warn("skipping commit: unknown commit in commits file", "hash", rev)
+		}
+		if len(hashes) == 0 {
+			return nil, nil
+		}
+		listed = hashes
+		// Keep the file's order instead of walking history.
+		args = append(args, "--no-walk=unsorted", "--stdin")
+	} else {
+		args = append(append(args, "--reverse"), cfg.revListOrder()...)
+		args = append(args, cfg.revisionRange())
+		args = append(args, cfg.pathspec()...)
+	}
 	if cfg.CommitLimit > 0 {
 		args = append(args, "-n", fmt.Sprintf("%d", cfg.CommitLimit))
 	}
 
 	cmd := exec.Command("git", args...)
+	if listed != nil {
+		cmd.Stdin = strings.NewReader(strings.Join(listed, "\n") + "\n")
+	}
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout pipe for git log metadata: %v", err)
@@ -506,6 +991,7 @@ func loadCommitMetadata(cfg Config) ([]*commitInfo, error) {
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start git log metadata: %v", err)
 	}
+	slog.Debug("started subprocess", "args", cmd.Args, "pid", cmd.Process.Pid)
 
 	scanner := bufio.NewScanner(stdout)
 	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
@@ -513,19 +999,23 @@ func loadCommitMetadata(cfg Config) ([]*commitInfo, error) {
 	var commits []*commitInfo
 	for scanner.Scan() {
 		line := scanner.Text()
-		parts := strings.SplitN(line, "\x1f", 4)
-		if len(parts) < 4 {
+		parts := strings.SplitN(line, "\x1f", 6)
+		if len(parts) < 6 {
 			continue
 		}
 		parsedDate, err := time.Parse(time.RFC3339, parts[2])
 		if err != nil {
-			parsedDate = time.Now()
+			parsedDate = time.Time{} // Reported as an unknown date
 		}
+		parents := strings.Fields(parts[3])
 		commits = append(commits, &commitInfo{
-			Hash:    parts[0],
-			Author:  parts[1],
-			Date:    parsedDate,
-			Message: parts[3],
+			Hash:         parts[0],
+			Author:       parts[1],
+			Date:         parsedDate,
+			ParentHashes: parents,
+			ParentCount:  len(parents),
+			Committer:    parts[4],
+			Message:      parts[5],
 		})
 	}
 
@@ -535,6 +1025,23 @@ func loadCommitMetadata(cfg Config) ([]*commitInfo, error) {
 	if err := cmd.Wait(); err != nil {
 		return nil, fmt.Errorf("git log metadata failed: %v", err)
 	}
+	slog.Debug("subprocess finished", "args", cmd.Args, "commits", len(commits))
+
+	if reflog != nil {
+		// One timeline entry per ref movement, even when a commit repeats.
+		byHash := make(map[string]*commitInfo, len(commits))
+		for _, c := range commits {
+			byHash[c.Hash] = c
+		}
+		commits = commits[:0:0]
+		for _, e := range reflog {
+			if c, ok := byHash[e.hash]; ok {
+				entry := *c
+				e.apply(&entry)
+				commits = append(commits, &entry)
+			}
+		}
+	}
 
 	if cfg.ReportSamplePct > 0 && cfg.ReportSamplePct < 100 && len(commits) > 0 {
 		target := (len(commits) * cfg.ReportSamplePct) / 100
@@ -549,7 +1056,7 @@ func loadCommitMetadata(cfg Config) ([]*commitInfo, error) {
 	return commits, nil
 }
 
-func runGitNumstat(repoPath string, hashes []string, onCommit func()) (map[string]commitStats, error) {
+func runGitNumstat(repoPath string, hashes []string, collectPaths bool, onCommit func()) (map[string]commitStats, error) {
 	if len(hashes) == 0 {
 		return map[string]commitStats{}, nil
 	}
@@ -576,6 +1083,7 @@ func runGitNumstat(repoPath string, hashes []string, onCommit func()) (map[strin
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start git show: %v", err)
 	}
+	slog.Debug("started subprocess", "args", cmd.Args, "pid", cmd.Process.Pid, "hashes", len(hashes))
 
 	go func() {
 		for _, h := range hashes {
@@ -615,6 +1123,9 @@ func runGitNumstat(repoPath string, hashes []string, onCommit func()) (map[strin
 				continue
 			}
 			current.files++
+			if collectPaths {
+				current.paths = append(current.paths, fields[2])
+			}
 			add, del := 0, 0
 			if fields[0] != "-" {
 				fmt.Sscanf(fields[0], "%d", &add)
@@ -622,6 +1133,11 @@ func runGitNumstat(repoPath string, hashes []string, onCommit func()) (map[strin
 			if fields[1] != "-" {
 				fmt.Sscanf(fields[1], "%d", &del)
 			}
+			if current.dirChurn == nil {
+				current.dirChurn = make(map[string]int)
+			}
+			current.dirChurn[topLevelDir(fields[2])] += add + del
+			current.fileStats = append(current.fileStats, fileStat{Name: fields[2], Additions: add, Deletions: del})
 			current.additions += add
 			current.deletions += del
 			current.churn += add + del
@@ -639,6 +1155,7 @@ func runGitNumstat(repoPath string, hashes []string, onCommit func()) (map[strin
 	if err := cmd.Wait(); err != nil {
 		return nil, fmt.Errorf("git show failed: %v", err)
 	}
+	slog.Debug("subprocess finished", "args", cmd.Args, "pid", cmd.Process.Pid)
 
 	emit()
 
@@ -667,10 +1184,152 @@ func (m *Model) progressTickCmd() tea.Cmd {
 	})
 }
 
+func parentHashes(c *object.Commit) []string {
+	hashes := make([]string, 0, len(c.ParentHashes))
+	for _, h := range c.ParentHashes {
+		hashes = append(hashes, h.String())
+	}
+	return hashes
+}
+
+// defaultDiffContext matches the context go-git uses when producing patches.
+const defaultDiffContext = 3
+
+// diffCacheSize caps how many non-default diffs are kept; the least recently
+// viewed one is dropped first.
+const diffCacheSize = 32
+
+type diffCacheKey struct {
+	hash             string
+	context          int
+	ignoreWhitespace bool
+}
+
+// diffCacheEntry keeps a diff together with its lines so neither has to be
+// rebuilt when the same options come around again.
+type diffCacheEntry struct {
+	text  string
+	lines []string
+}
+
+// setCurrentDiff replaces the diff on screen. lines may be nil, in which case
+// text is split here; either way it is split once per load, not per frame.
+func (m *Model) setCurrentDiff(owner, text string, lines []string) {
+	if lines == nil {
+		lines = strings.Split(text, "\n")
+	}
+	m.currentDiff, m.currentDiffLines, m.currentDiffFor = text, lines, owner
+}
+
+// dropStaleDiff releases the diff on screen once the selected commit has moved
+// away from it outside the diff view, so a large diff isn't kept alive.
+func (m *Model) dropStaleDiff() {
+	if m.currentDiffLines == nil || m.diffState == inDiffView {
+		return
+	}
+	if len(m.commits) == 0 || m.commits[m.currentCommitIndex].Hash != m.currentDiffFor {
+		m.currentDiff, m.currentDiffLines, m.currentDiffFor = "", nil, ""
+		m.diffLineEndings, m.diffFileStarts, m.diffNumbers, m.diffPairs = nil, nil, nil, nil
+	}
+}
+
+// loadCurrentDiff sets currentDiff to the diff of the selected commit using the
+// chosen context size and whitespace handling. The default diff comes from
+// go-git; anything else falls back to git itself since go-git has no options.
+func (m *Model) loadCurrentDiff() {
+	if len(m.commits) == 0 {
+		return
+	}
+	m.diffIsRange = false
+	currentCommit := m.commits[m.currentCommitIndex]
+	m.loadCommitDiff(currentCommit)
+	m.currentDiffLines = append(m.messageLines(currentCommit), m.currentDiffLines...)
+	m.indexDiffLines()
+}
+
+// messageLines renders the full commit message for the top of the diff view,
+// wrapped to messageWrapWidth or the view width.
+func (m *Model) messageLines(c *commitInfo) []string {
+	width := m.config.MessageWrapWidth
+	if width <= 0 {
+		width = m.width
+	}
+	var lines []string
+	for _, line := range wrapMessage(c.Message, width-len(messageIndent)) {
+		lines = append(lines, messageIndent+line)
+	}
+	if m.config.ShowSigner {
+		lines = append(lines, "", messageIndent+m.signatureLine(c))
+	}
+	return append(lines, "")
+}
+
+func (m *Model) loadCommitDiff(currentCommit *commitInfo) {
+	if m.diffContext == defaultDiffContext && !m.diffIgnoreWhitespace {
+		diff, err := getDiff(m.repo, currentCommit)
+		if err != nil {
+			m.setCurrentDiff(currentCommit.Hash, fmt.Sprintf("Error getting diff: %v", err), nil)
+			return
+		}
+		m.setCurrentDiff(currentCommit.Hash, diff, currentCommit.DiffLines)
+		return
+	}
+
+	key := diffCacheKey{hash: currentCommit.Hash, context: m.diffContext, ignoreWhitespace: m.diffIgnoreWhitespace}
+	cached, ok := m.diffCache[key]
+	if ok {
+		m.diffCacheOrder = append(slices.DeleteFunc(m.diffCacheOrder, func(k diffCacheKey) bool { return k == key }), key)
+	} else {
+		opts := []string{fmt.Sprintf("-U%d", m.diffContext)}
+		if m.diffIgnoreWhitespace {
+			opts = append(opts, "-w")
+		}
+		diff, err := gitDiff(m.config.RepoPath, currentCommit.Hash, opts...)
+		if err != nil {
+			m.setCurrentDiff(currentCommit.Hash, fmt.Sprintf("Error getting diff: %v", err), nil)
+			return
+		}
+		cached = diffCacheEntry{text: diff, lines: strings.Split(diff, "\n")}
+		m.diffCache[key] = cached
+		m.diffCacheOrder = append(m.diffCacheOrder, key)
+		if len(m.diffCacheOrder) > diffCacheSize {
+			delete(m.diffCache, m.diffCacheOrder[0])
+			m.diffCacheOrder = slices.Delete(m.diffCacheOrder, 0, 1)
+		}
+	}
+	m.setCurrentDiff(currentCommit.Hash, cached.text, cached.lines)
+}
+
+// reloadDiff regenerates whichever diff is on screen after an option change.
+func (m *Model) reloadDiff() {
+	if m.diffIsRange {
+		m.loadRangeDiff()
+		return
+	}
+	m.loadCurrentDiff()
+}
+
+// gitDiff runs git to diff a commit against its first parent (or the empty tree
+// for a root commit) with extra diff options.
+func gitDiff(repoPath, hash string, opts ...string) (string, error) {
+	args := append([]string{"-C", repoPath, "show", "--no-color", "--pretty=format:", "--diff-merges=first-parent"}, opts...)
+	args = append(args, hash)
+	cmd := exec.Command("git", args...)
+	slog.Debug("running subprocess", "args", cmd.Args)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git show failed: %v", err)
+	}
+	return strings.TrimLeft(string(out), "\n"), nil
+}
+
 func getDiff(r *git.Repository, commit *commitInfo) (string, error) {
 	if commit.DiffContent != "" {
 		return commit.DiffContent, nil
 	}
+	if r == nil {
+		return "", fmt.Errorf("no repository loaded")
+	}
 
 	hash := plumbing.NewHash(commit.Hash)
 	commitObject, err := r.CommitObject(hash)
@@ -690,6 +1349,7 @@ func getDiff(r *git.Repository, commit *commitInfo) (string, error) {
 			return "", err
 		}
 		commit.DiffContent = patch.String()
+		commit.DiffLines = strings.Split(commit.DiffContent, "\n")
 		return commit.DiffContent, nil
 	}
 
@@ -711,81 +1371,147 @@ func getDiff(r *git.Repository, commit *commitInfo) (string, error) {
 	}
 
 	commit.DiffContent = patch.String()
+	commit.DiffLines = strings.Split(commit.DiffContent, "\n")
 	return commit.DiffContent, nil
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	resume := m.wakeTicks(msg)
+	model, cmd := m.update(msg)
+	if resume {
+		cmd = tea.Batch(cmd, m.progressTickCmd())
+	}
+	if load := m.fileStatsCmd(); load != nil {
+		cmd = tea.Batch(cmd, load)
+	}
+	return model, cmd
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer m.developerStats()
+	defer m.syncStatYears()
+	defer m.dropStaleDiff()
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		if m.noteInput(m.nowFunc()) {
+			return m, nil
+		}
 		if m.diffState == inDiffView {
-			switch msg.String() {
-			case "q", "ctrl+c", "esc", "enter":
+			m.diffNotice = ""
+			if m.diffSearchPrompt {
+				m.editDiffSearch(msg)
+				return m, nil
+			}
+			if msg.String() == "esc" && m.diffSearch != nil {
+				m.diffSearch = nil
+				return m, nil
+			}
+			switch m.keys.diffAction(msg.String()) {
+			case actionSearch:
+				m.diffSearchPrompt, m.diffSearchInput = true, ""
+				return m, nil
+			case actionNextMatch:
+				m.jumpToMatch(1, m.diffScroll)
+				return m, nil
+			case actionPrevMatch:
+				m.jumpToMatch(-1, m.diffScroll)
+				return m, nil
+			case actionOpenPager:
+				return m, m.openPager()
+			case actionSaveDiff:
+				m.saveDiff()
+				return m, nil
+			case actionExitDiff:
 				m.diffState = notInDiffView
 				return m, nil
-			case "up", "k":
-				m.diffScroll--
-				if m.diffScroll < 0 {
-					m.diffScroll = 0
-				}
+			case actionScrollUp:
+				m.scrollDiff(-1)
 				return m, nil
-			case "down", "j":
-				m.diffScroll++
+			case actionScrollDown:
+				m.scrollDiff(1)
 				return m, nil
-			case "pgup":
-				m.diffScroll -= m.height
-				if m.diffScroll < 0 {
-					m.diffScroll = 0
-				}
+			case actionPageUp:
+				m.scrollDiff(-m.height)
 				return m, nil
-			case "pgdown", "space":
-				m.diffScroll += m.height
+			case actionPageDown:
+				m.scrollDiff(m.height)
 				return m, nil
-			case "left", "h":
+			case actionNextFile:
+				m.jumpToFile(1)
+				return m, nil
+			case actionPrevFile:
+				m.jumpToFile(-1)
+				return m, nil
+			case actionLineNumbers:
+				m.showLineNumbers = !m.showLineNumbers
+				return m, nil
+			case actionToggleWrap:
+				m.diffWrap = !m.diffWrap
+				return m, nil
+			case actionToggleSplit:
+				m.splitDiff = !m.splitDiff
+				return m, nil
+			case actionMoreContext:
+				m.diffContext++
+				m.reloadDiff()
+				return m, nil
+			case actionToggleWhitespace:
+				m.diffIgnoreWhitespace = !m.diffIgnoreWhitespace
+				m.reloadDiff()
+				return m, nil
+			case actionToggleEOL:
+				m.diffNormalizeEOL = !m.diffNormalizeEOL
+				return m, nil
+			case actionLessContext:
+				if m.diffContext > 0 {
+					m.diffContext--
+					m.reloadDiff()
+				}
+				return m, nil
+			case actionPrev:
 				m.autoProgress = false
 				if m.currentCommitIndex > 0 {
 					m.currentCommitIndex--
-					currentCommit := m.commits[m.currentCommitIndex]
-					diff, err := getDiff(m.repo, currentCommit)
-					if err != nil {
-						m.currentDiff = fmt.Sprintf("Error getting diff: %v", err)
-					} else {
-						m.currentDiff = diff
-					}
+					m.loadCurrentDiff()
 					m.diffScroll = 0
 				}
 				return m, nil
-			case "right", "l":
+			case actionNext:
 				m.autoProgress = false
 				if m.currentCommitIndex < len(m.commits)-1 {
 					m.currentCommitIndex++
-					currentCommit := m.commits[m.currentCommitIndex]
-					diff, err := getDiff(m.repo, currentCommit)
-					if err != nil {
-						m.currentDiff = fmt.Sprintf("Error getting diff: %v", err)
-					} else {
-						m.currentDiff = diff
-					}
+					m.loadCurrentDiff()
 					m.diffScroll = 0
 				}
 				return m, nil
 			}
 		} else {
-			switch msg.String() {
-			case "q", "ctrl+c":
+			m.notice = ""
+			action := m.keys.mainAction(msg.String())
+			if m.tourPaused && (action == "" || action == actionToggleAuto) {
+				m.resumeTour()
+				return m, nil
+			}
+			// Taking over before the saved position loads cancels resuming.
+			m.resumeHash, m.showDiffHash = "", ""
+			switch action {
+			case actionQuit:
+				m.savePosition()
+				m.recorder.finish()
 				return m, tea.Quit
-			case "right", "l":
+			case actionNext:
 				m.autoProgress = false
 				if m.currentCommitIndex < len(m.commits)-1 {
 					m.currentCommitIndex++
 				}
 				return m, nil
-			case "left", "h":
+			case actionPrev:
 				m.autoProgress = false
 				if m.currentCommitIndex > 0 {
 					m.currentCommitIndex--
 				}
 				return m, nil
-			case "up", "k":
+			case actionYearPrev:
 				if len(m.availableStatYears) > 0 {
 					m.currentStatYearIndex--
 					if m.currentStatYearIndex < 0 {
@@ -794,84 +1520,186 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.displayedStatsYear = m.availableStatYears[m.currentStatYearIndex]
 				}
 				return m, nil
-			case "down", "j":
+			case actionYearNext:
 				if len(m.availableStatYears) > 0 {
 					m.currentStatYearIndex = (m.currentStatYearIndex + 1) % len(m.availableStatYears)
 					m.displayedStatsYear = m.availableStatYears[m.currentStatYearIndex]
 				}
 				return m, nil
-			case "p", "space": // Toggle auto-progression
+			case actionToggleAuto:
 				m.autoProgress = !m.autoProgress
 				return m, nil
-			case "enter":
+			case actionCycleGraphMode:
+				m.cycleGraphMode()
+				return m, nil
+			case actionToggleGraph:
+				m.showGraph = !m.showGraph
+				return m, nil
+			case actionDaySeparators:
+				m.showDaySeparators = !m.showDaySeparators
+				return m, nil
+			case actionToggleDirs:
+				m.showDirChart = !m.showDirChart
+				return m, nil
+			case actionFlipGraph:
+				m.deletionsOnTop = !m.deletionsOnTop
+				return m, nil
+			case actionCycleZeroLine:
+				m.cycleZeroLine()
+				return m, nil
+			case actionToggleFullHash:
+				m.state.FullHashes = !m.state.FullHashes
+				saveState(m.state)
+				return m, nil
+			case actionCatchUp, actionCatchUpPlay:
+				m.tourPaused = false
+				m.autoProgress = action == actionCatchUpPlay
+				m.notice = fmt.Sprintf("Caught up, skipped %d", m.catchUp())
+				return m, nil
+			case actionSeekDecile:
+				// Digits pick the decile; other keys bound here are ignored.
+				if k := msg.String(); len(k) == 1 && k[0] >= '0' && k[0] <= '9' {
+					m.seekDecile(int(k[0] - '0'))
+				}
+				return m, nil
+			case actionFirst:
+				m.seek(0)
+				return m, nil
+			case actionLast:
+				m.seek(len(m.commits) - 1)
+				return m, nil
+			case actionSpeedUp, actionSlowDown:
+				m.changeSpeed(action == actionSpeedUp)
+				return m, nil
+			case actionNextPanel:
+				m.cyclePanel(1)
+				return m, nil
+			case actionPrevPanel:
+				m.cyclePanel(-1)
+				return m, nil
+			case actionDumpState:
+				m.dumpState()
+				return m, nil
+			case actionToggleIdentity:
+				m.byCommitter = !m.byCommitter
+				return m, nil
+			case actionNextAuthor, actionPrevAuthor:
+				m.moveAuthorCursor(action == actionNextAuthor)
+				return m, nil
+			case actionPickAuthor:
+				m.pickAuthor()
+				return m, nil
+			case actionNextAnnotation:
+				m.jumpAnnotation(1)
+				return m, nil
+			case actionPrevAnnotation:
+				m.jumpAnnotation(-1)
+				return m, nil
+			case actionToggleStatsMode:
+				m.statsPerCommit = !m.statsPerCommit
+				return m, nil
+			case actionToggleLifetimes:
+				m.showLifetimes = !m.showLifetimes
+				return m, nil
+			case actionCycleSort:
+				m.contributorSort = (m.contributorSort + 1) % numContributorSorts
+				return m, nil
+			case actionMarkA:
+				m.markCommit(true)
+				return m, nil
+			case actionMarkB:
+				m.markCommit(false)
+				return m, nil
+			case actionCompareDiff:
+				if m.markA != "" && m.markB != "" {
+					m.autoProgress = false
+					m.diffState = inDiffView
+					m.diffScroll = 0
+					m.loadRangeDiff()
+				}
+				return m, nil
+			case actionEnterDiff:
+				if m.config.FromJSON != "" && m.repo == nil {
+					m.notice = "Diffs unavailable"
+					return m, nil
+				}
 				if !m.autoProgress {
 					m.diffState = inDiffView
 					m.diffScroll = 0
-					currentCommit := m.commits[m.currentCommitIndex]
-					diff, err := getDiff(m.repo, currentCommit)
-					if err != nil {
-						m.currentDiff = fmt.Sprintf("Error getting diff: %v", err)
-					} else {
-						m.currentDiff = diff
-					}
+					m.loadCurrentDiff()
 				}
 				return m, nil
 			}
 		}
 
+	case tea.ColorProfileMsg:
+		slog.Debug("detected color profile", "profile", msg.Profile)
+		applyColorProfile(msg.Profile)
+
 	case tea.WindowSizeMsg:
-		m.width = msg.Width - 10
-		m.height = msg.Height - 10
-		m.graphColumns = m.width/2 - 10
-		m.networkGraphHeight = m.height/3 - 10
+		m.lastInput = m.nowFunc()
+		m.resize(msg.Width, msg.Height)
 
 	case progressTickMsg:
-		if m.autoProgress {
+		// Compare against the model clock rather than the tick's wall time so
+		// idle timing stays consistent with lastInput.
+		now := m.nowFunc()
+		m.debug.countTick()
+		m.idleTick(now)
+		if m.autoProgress && m.config.CommitsPerTick <= 0 && m.playbackBehind() {
+			// Playback was resumed after stepping back: replay the loaded
+			// commits first and only then go back to following the fetcher.
+			m.advancePlayback(1)
+		} else if m.autoProgress {
+			// With commitsPerTick unset the playhead follows loading; otherwise
+			// it trails behind and advances a fixed number of commits per tick.
+			follow := m.config.CommitsPerTick <= 0
 			const maxPerTick = 200
 			for i := 0; i < maxPerTick; i++ {
 				select {
 				case newCommit, ok := <-m.processedCommitsChan:
 					if ok {
-						// Atomically process the new commit and update the index
-						newCommit.DiffLoaded = true
-
-						if len(m.commits) > 0 {
-							lastCommit := m.commits[len(m.commits)-1]
-							newCommit.CumulativeFiles = lastCommit.CumulativeFiles + newCommit.Files
-							newCommit.CumulativeAdditions = lastCommit.CumulativeAdditions + newCommit.Additions
-							newCommit.CumulativeDeletions = lastCommit.CumulativeDeletions + newCommit.Deletions
-						} else {
-							newCommit.CumulativeFiles = newCommit.Files
-							newCommit.CumulativeAdditions = newCommit.Additions
-							newCommit.CumulativeDeletions = newCommit.Deletions
-						}
-
-						if newCommit.Additions > m.maxAdditions {
-							m.maxAdditions = newCommit.Additions
-						}
-						if newCommit.Deletions > m.maxDeletions {
-							m.maxDeletions = newCommit.Deletions
+						m.appendCommit(newCommit)
+						if m.resumeAt(newCommit, len(m.commits)-1) || m.showDiffAt(newCommit, len(m.commits)-1) {
+							i = maxPerTick
+						} else if follow {
+							m.currentCommitIndex = len(m.commits) - 1
+							if m.tourStop(newCommit) {
+								m.autoProgress = false
+								m.tourPaused = true
+								i = maxPerTick
+							}
 						}
-
-						m.commits = append(m.commits, newCommit)
-						m.currentCommitIndex = len(m.commits) - 1
-
 					} else {
 						m.loadingComplete = true
+						m.missedShowDiff()
 						i = maxPerTick
 					}
 				default:
 					i = maxPerTick
 				}
 			}
+			if !follow && m.autoProgress {
+				m.advancePlayback(m.config.CommitsPerTick)
+			}
+		}
+		if m.shouldStopTicks(now) {
+			m.ticksStopped = true
+			return m, nil
 		}
 		return m, m.progressTickCmd()
 
+	case tagsLoadedMsg:
+		m.tags = msg.tags
+
+	case fileStatsLoadedMsg:
+		m.setFileStats(msg)
+
 	case reportLoadedMsg:
 		m.repo = msg.repo
 		m.commits = msg.commits
-		m.maxAdditions = msg.maxAdditions
-		m.maxDeletions = msg.maxDeletions
+		m.recomputeMaxima()
+		m.resetStatYears()
 		m.reportTotal = msg.total
 		m.reportProcessed = msg.total
 		m.reportWorkers = msg.workers
@@ -885,10 +1713,28 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.loadingComplete = true
 		m.autoProgress = false
+		for i, c := range m.commits {
+			if m.resumeAt(c, i) || m.showDiffAt(c, i) {
+				break
+			}
+		}
+		m.resumeHash = ""
+		m.missedShowDiff()
+		return m, nil
+
+	case pagerFinishedMsg:
+		if msg.err != nil {
+			m.diffNotice = fmt.Sprintf("pager failed: %v", msg.err)
+		}
+		return m, nil
+
+	case commitSkippedMsg:
+		m.skippedCommits = append(m.skippedCommits, msg.hash)
 		return m, nil
 
 	case reportProgressMsg:
 		m.reportProcessed = msg.processed
+		m.reportETA.observe(m.nowFunc(), msg.processed)
 		m.reportTotal = msg.total
 		m.reportWorkers = msg.workers
 		if msg.engine != "" {
@@ -896,6 +1742,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case debugTickMsg:
+		m.debug.sample(time.Time(msg))
+		return m, m.debugTickCmd()
+
 	case errMsg:
 		return m, tea.Quit
 	}
@@ -909,17 +1759,28 @@ var (
 	statsLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Align(lipgloss.Right).Width(12)
 	statsValueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("117")).Bold(true).Align(lipgloss.Left).Width(12)
 
-	barChar           = "█"
-	barStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
-	barLabelStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Width(8).Align(lipgloss.Right)
-	barValueStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Align(lipgloss.Left).Width(7)
-	barMessageStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("247")).Align(lipgloss.Left)
-	barHighlightStyle = lipgloss.NewStyle().Background(lipgloss.Color("236"))
+	barChar         = "█"
+	barStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+	barLabelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Width(8).Align(lipgloss.Right)
+	barValueStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Align(lipgloss.Left).Width(7)
+	barMessageStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("247")).Align(lipgloss.Left)
 
 	additionStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("118")) // Bright green
 	deletionStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("203")) // Bright red
 	graphAxisStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
 	graphHighlight = lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Bold(true)
+	warningStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+	signedGlyphStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("118"))
+	unsignedGlyphStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	alertStyle           = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+	dagStyle             = lipgloss.NewStyle().Foreground(lipgloss.Color("111"))
+	mergeGlyphStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("141"))
+	annotationStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("219")).Bold(true)
+	changedAdditionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("120")).Background(lipgloss.Color("22"))
+	changedDeletionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("217")).Background(lipgloss.Color("52"))
+	tagStyle             = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	searchMatchStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("16")).Background(lipgloss.Color("214"))
 
 	additionGradient = []color.Color{
 		lipgloss.Color("#E6FFE6"),
@@ -951,14 +1812,14 @@ func (m *Model) renderPanelWithHeader(title string, content string, width int, h
 	panel := lipgloss.NewStyle().
 		Width(width).
 		Height(height).
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("239"))
+		Border(panelBorder).
+		BorderForeground(panelBorderColor)
 
 	header := lipgloss.NewStyle().
 		Width(width - 2).
 		Align(lipgloss.Center).
 		Bold(true).
-		Foreground(lipgloss.Color("147")).
+		Foreground(panelTitleColor).
 		Render("[ " + title + " ]")
 
 	contentArea := lipgloss.NewStyle().
@@ -980,7 +1841,7 @@ func (m *Model) renderBrailleGraph(graphHeight int) string {
 	}
 
 	// Each braille character can hold 2 pixels horizontally, so we can fit 2 commits per character
-	canvas := NewBrailleCanvas(m.graphColumns*2, graphHeight*4)
+	canvas := m.changesCanvas(m.graphColumns*2, graphHeight*4)
 
 	displayCommits := m.commits[:m.currentCommitIndex+1]
 
@@ -989,7 +1850,20 @@ func (m *Model) renderBrailleGraph(graphHeight int) string {
 	startIndex := max(0, len(displayCommits)-windowSize)
 	endIndex := len(displayCommits)
 
-	zeroLine := canvas.Height / 2
+	if m.graphMode == graphModeCadence {
+		return m.renderCadenceGraph(graphHeight, startIndex, endIndex)
+	}
+	if m.graphMode == graphModeSize {
+		m.renderSizeGraph(canvas, startIndex, endIndex)
+		return barStyle.Render(strings.TrimSuffix(canvas.String(), "\n"))
+	}
+
+	zeroLine := m.zeroLine(canvas.Height)
+	upperRoom, lowerRoom := zeroLine-1, canvas.Height-zeroLine-1
+	addRoom, delRoom := upperRoom, lowerRoom
+	if m.deletionsOnTop {
+		addRoom, delRoom = lowerRoom, upperRoom
+	}
 
 	for i := startIndex; i < endIndex; i++ {
 		c := displayCommits[i]
@@ -1000,6 +1874,9 @@ func (m *Model) renderBrailleGraph(graphHeight int) string {
 		if pixelX < 0 || pixelX >= m.graphColumns*2 {
 			continue
 		}
+		if m.config.InitialCommit == initialCommitHide && !m.scalesCommit(c) {
+			continue
+		}
 
 		logMaxAdd := math.Log1p(float64(m.maxAdditions))
 		if logMaxAdd == 0 {
@@ -1010,30 +1887,70 @@ func (m *Model) renderBrailleGraph(graphHeight int) string {
 			logMaxDel = 1
 		}
 
-		scaledAdditions := 0
-		if c.Additions > 0 {
-			scaledAdditions = int((math.Log1p(float64(c.Additions)) / logMaxAdd) * float64(zeroLine-1))
+		additions, deletions := graphStats(c)
+		scaledAdditions, scaledDeletions := 0, 0
+		if m.graphMode == graphModeProportional {
+			if additions+deletions == 0 {
+				continue
+			}
+			// Each side is split in its own room, so a biased zero line
+			// still fills both.
+			scaledAdditions, _ = scaleProportional(additions, deletions, addRoom)
+			_, scaledDeletions = scaleProportional(additions, deletions, delRoom)
+		} else {
+			if additions > 0 {
+				scaledAdditions = int((math.Log1p(float64(additions)) / logMaxAdd) * float64(addRoom))
+			}
+			if deletions > 0 {
+				scaledDeletions = int((math.Log1p(float64(deletions)) / logMaxDel) * float64(delRoom))
+			}
 		}
-		scaledDeletions := 0
-		if c.Deletions > 0 {
-			scaledDeletions = int((math.Log1p(float64(c.Deletions)) / logMaxDel) * float64(zeroLine-1))
+		// Commits left out of the scale can exceed it; clip them to the canvas.
+		scaledAdditions = min(scaledAdditions, addRoom)
+		scaledDeletions = min(scaledDeletions, delRoom)
+
+		above, below := scaledAdditions, scaledDeletions
+		if m.deletionsOnTop {
+			above, below = below, above
 		}
 
-		// Draw additions (upward from zero line) for this single pixel column
-		for y := 0; y <= scaledAdditions; y++ {
+		// Draw the upper series (additions unless flipped) upward from the zero line
+		for y := 0; y <= above; y++ {
 			canvas.Set(pixelX, zeroLine-y)
 		}
 
-		// Draw deletions (downward from zero line) for this single pixel column
-		for y := 0; y <= scaledDeletions; y++ {
+		// Draw the lower series downward from the zero line
+		for y := 0; y <= below; y++ {
 			canvas.Set(pixelX, zeroLine+y)
 		}
 	}
 
-	return m.colorizeBraille(canvas)
+	return m.colorizeBraille(canvas, zeroLine)
 }
 
-func (m *Model) colorizeBraille(canvas *BrailleCanvas) string {
+// changesCanvas returns the changes graph canvas cleared for a new frame,
+// allocating it again only when the graph size changes.
+func (m *Model) changesCanvas(width, height int) *BrailleCanvas {
+	if m.graphCanvas == nil || m.graphCanvas.Width != width || m.graphCanvas.Height != height {
+		m.graphCanvas = NewBrailleCanvas(width, height)
+	} else {
+		m.graphCanvas.Clear()
+	}
+	return m.graphCanvas
+}
+
+func (m *Model) colorizeBraille(canvas *BrailleCanvas, zeroLine int) string {
+	// Both gradients run from the outer edge towards the zero line; flipping
+	// the graph swaps the bands and mirrors them.
+	upper, lower := additionGradient, deletionGradient
+	if m.deletionsOnTop {
+		upper, lower = reversedColors(deletionGradient), reversedColors(additionGradient)
+	}
+
+	// The bands meet at the character row holding the zero line.
+	zeroRow := max(zeroLine/4, 1)
+	lowerRows := max(canvas.Height/4-zeroRow, 1)
+
 	var coloredFrame strings.Builder
 	frame := canvas.String()
 	for y, line := range strings.Split(frame, "\n") {
@@ -1042,20 +1959,20 @@ func (m *Model) colorizeBraille(canvas *BrailleCanvas) string {
 				coloredFrame.WriteString(" ")
 			} else {
 				color := lipgloss.Color("#FFFFFF") // Default color
-				if y < canvas.Height/8 {
-					// Additions
-					colorIndex := int(float64(y) / float64(canvas.Height/8) * float64(len(additionGradient)))
-					if colorIndex >= len(additionGradient) {
-						colorIndex = len(additionGradient) - 1
+				if y < zeroRow {
+					// Above the zero line
+					colorIndex := int(float64(y) / float64(zeroRow) * float64(len(upper)))
+					if colorIndex >= len(upper) {
+						colorIndex = len(upper) - 1
 					}
-					color = additionGradient[colorIndex]
+					color = upper[colorIndex]
 				} else {
-					// Deletions
-					colorIndex := int(float64(y-canvas.Height/8) / float64(canvas.Height/8) * float64(len(deletionGradient)))
-					if colorIndex >= len(deletionGradient) {
-						colorIndex = len(deletionGradient) - 1
+					// Below the zero line
+					colorIndex := int(float64(y-zeroRow) / float64(lowerRows) * float64(len(lower)))
+					if colorIndex >= len(lower) {
+						colorIndex = len(lower) - 1
 					}
-					color = deletionGradient[colorIndex]
+					color = lower[colorIndex]
 				}
 				coloredFrame.WriteString(lipgloss.NewStyle().Foreground(color).Render(string(char)))
 			}
@@ -1066,12 +1983,24 @@ func (m *Model) colorizeBraille(canvas *BrailleCanvas) string {
 	return coloredFrame.String()
 }
 
+func reversedColors(colors []color.Color) []color.Color {
+	out := make([]color.Color, len(colors))
+	for i, c := range colors {
+		out[len(colors)-1-i] = c
+	}
+	return out
+}
+
 func (m *Model) renderDiffView() string {
-	lines := strings.Split(m.currentDiff, "\n")
+	lines := m.currentDiffLines
+
+	var builder strings.Builder
+	builder.WriteString(m.renderDiffStatus())
+	builder.WriteString("\n")
 
 	// Handle scrolling
 	start := m.diffScroll
-	end := start + m.height
+	end := start + m.height - 1
 	if start < 0 {
 		start = 0
 	}
@@ -1082,30 +2011,155 @@ func (m *Model) renderDiffView() string {
 		start = end
 	}
 
+	if m.splitDiff {
+		builder.WriteString(m.renderSplitDiff(m.height - 1))
+		return builder.String()
+	}
+	if m.diffWrap {
+		builder.WriteString(m.renderWrappedDiff(m.height - 1))
+		return builder.String()
+	}
+
 	visibleLines := lines[start:end]
 
-	var builder strings.Builder
-	for _, line := range visibleLines {
-		style := lipgloss.NewStyle()
-		if strings.HasPrefix(line, "+") {
-			style = additionStyle
-		} else if strings.HasPrefix(line, "-") {
-			style = deletionStyle
-		}
-		builder.WriteString(style.Render(line))
+	var lang *syntaxLanguage
+	if m.config.SyntaxHighlight {
+		lang = m.diffLanguageAt(start)
+	}
+	pairs := m.wordDiffPairs()
+	for i, line := range visibleLines {
+		if m.config.SyntaxHighlight {
+			if l, ok := diffFileLanguage(line); ok {
+				lang = l
+			}
+		}
+		builder.WriteString(m.lineNumberGutter(start + i))
+		builder.WriteString(m.renderDiffLine(start+i, lang, -1, pairs))
+		if eol, ok := m.diffLineEndings[start+i]; ok {
+			builder.WriteString(graphAxisStyle.Render("  " + eol.label()))
+		}
 		builder.WriteString("\n")
 	}
 
 	return builder.String()
 }
 
+// renderDiffLine colors diff line i, clipping it to width columns unless
+// width is negative. A line paired with the one it replaces has the changed
+// words picked out.
+func (m *Model) renderDiffLine(i int, lang *syntaxLanguage, width int, pairs map[int]int) string {
+	line := m.diffDisplayLine(m.currentDiffLines[i], width >= 0)
+	style, changedStyle := lipgloss.NewStyle(), lipgloss.NewStyle()
+	if strings.HasPrefix(line, "+") {
+		style, changedStyle = additionStyle, changedAdditionStyle
+	} else if strings.HasPrefix(line, "-") {
+		style, changedStyle = deletionStyle, changedDeletionStyle
+	}
+	shown := line
+	if width >= 0 {
+		if r := []rune(line); len(r) > width {
+			shown = string(r[:width])
+		}
+	}
+	// Search matches take precedence over word and syntax colors.
+	if m.diffSearch != nil && m.diffSearch.MatchString(line) {
+		return m.highlightMatches(shown, style)
+	}
+	if j, ok := pairs[i]; ok {
+		if spans, ok := m.lcs.changedSpans(line, m.diffDisplayLine(m.currentDiffLines[j], width >= 0)); ok {
+			return renderWordDiff(shown, spans, style, changedStyle)
+		}
+	}
+	if lang != nil && isDiffCodeLine(shown) {
+		return highlightCode(shown, lang, style)
+	}
+	return m.highlightMatches(shown, style)
+}
+
+// diffDisplayLine is line as the diff view shows it. Tabs are expanded when
+// the line will be clipped, so its length matches what is on screen.
+func (m *Model) diffDisplayLine(line string, clipped bool) string {
+	if m.diffNormalizeEOL {
+		line = normalizeDiffLine(line)
+	}
+	if clipped {
+		line = strings.ReplaceAll(line, "\t", "    ")
+	}
+	return line
+}
+
+// renderDiffStatus renders the one-line header shown above the diff.
+func (m *Model) renderDiffStatus() string {
+	status := fmt.Sprintf("context: %d lines", m.diffContext)
+	if m.diffIgnoreWhitespace {
+		status += "  ignoring whitespace"
+	}
+	if !m.diffNormalizeEOL {
+		status += "  raw line endings"
+	}
+	if m.splitDiff {
+		status += "  side by side"
+	} else if m.diffWrap {
+		status += "  wrapped"
+	}
+	if files := m.diffFileLabel(); files != "" {
+		status += "  " + files + " ([/])"
+	}
+	if m.diffIsRange {
+		status = m.markA[:7] + ".." + m.markB[:7] + "  " + status
+	} else if len(m.commits) > 0 {
+		status = m.shortHash(m.commits[m.currentCommitIndex].Hash) + "  " + status
+	}
+	if m.diffSearch != nil {
+		status += "  search: " + m.diffSearchInput + " (n/N, esc clears)"
+	}
+	status = graphAxisStyle.Render(status)
+	if m.diffSearchPrompt {
+		status += "  " + graphHighlight.Render("/"+m.diffSearchInput+"_")
+	}
+	if m.diffNotice != "" {
+		status += "  " + warningStyle.Render(m.diffNotice)
+	}
+	return status
+}
+
+// panelHeights splits the left column below the stats panel between the
+// changes graph and the timeline.
+func (m *Model) panelHeights(statsPanelHeight int) (changes, timeline int) {
+	changes = m.height*2/3 - 10
+	timeline = m.height - statsPanelHeight - changes
+	if timeline < 8 {
+		timeline = 8
+		changes = m.height - statsPanelHeight - timeline
+	}
+	return changes, timeline
+}
+
 func (m *Model) newView(content string) tea.View {
 	v := tea.NewView(content)
-	v.AltScreen = true
+	v.AltScreen = !m.config.NoAltScreen
 	return v
 }
 
+// View renders the dashboard, timing it and adding the overlay when
+// -debug-stats is on.
 func (m *Model) View() tea.View {
+	if m.debug == nil {
+		v := m.view()
+		m.recordFrame(v.Content)
+		return v
+	}
+	start := time.Now()
+	v := m.view()
+	m.debug.lastFrame = time.Since(start)
+	m.debug.renderTotal += m.debug.lastFrame
+	m.debug.renders++
+	v.Content = m.debug.overlay(v.Content, len(m.commits))
+	m.recordFrame(v.Content)
+	return v
+}
+
+func (m *Model) view() tea.View {
 	if m.config.ReportMode && !m.loadingComplete {
 		total := m.reportTotal
 		processed := m.reportProcessed
@@ -1118,13 +2172,17 @@ func (m *Model) View() tea.View {
 			return m.newView(fmt.Sprintf("Loading report... using %d workers (%s)", workers, engine))
 		}
 		percent := (float64(processed) / float64(total)) * 100
-		return m.newView(fmt.Sprintf("Loading report... %d/%d (%.1f%%) using %d workers (%s)", processed, total, percent, workers, engine))
+		eta := m.reportETA.label(m.nowFunc(), total)
+		return m.newView(fmt.Sprintf("Loading report... %d/%d (%.1f%%) using %d workers (%s)%s", processed, total, percent, workers, engine, eta))
 	}
 	if m.diffState == inDiffView {
 		return m.newView(m.renderDiffView())
 	}
 	if len(m.commits) == 0 {
-		return m.newView("Loading commits...")
+		if m.width <= 0 || m.height <= 0 {
+			return m.newView(tr("Loading commits..."))
+		}
+		return m.newView(m.renderSkeleton())
 	}
 
 	if m.currentCommitIndex >= len(m.commits) {
@@ -1132,59 +2190,215 @@ func (m *Model) View() tea.View {
 	}
 	currentCommit := m.commits[m.currentCommitIndex]
 
-	// Calculate author count dynamically
+	// Calculate author and alert counts dynamically
 	authorSet := make(map[string]struct{})
+	alertCount, duplicateCount, mergeCount := 0, 0, 0
+	noiseAdditions, noiseDeletions := 0, 0
 	for i := 0; i <= m.currentCommitIndex; i++ {
-		authorSet[m.commits[i].Author] = struct{}{}
+		authorSet[m.authorKey(m.commits[i].Author)] = struct{}{}
+		if len(m.commits[i].AlertPaths) > 0 {
+			alertCount++
+		}
+		if m.commits[i].DuplicateOf != "" {
+			duplicateCount++
+		}
+		if m.commits[i].ParentCount > 1 {
+			mergeCount++
+		}
+		noiseAdditions += m.commits[i].NoiseAdditions
+		noiseDeletions += m.commits[i].NoiseDeletions
 	}
 
 	statsBuilder := strings.Builder{}
 
-	statsBuilder.WriteString(fmt.Sprintf("  Author: %s\n", currentCommit.Author))
-	statsBuilder.WriteString(fmt.Sprintf("  Date: %s\n", currentCommit.Date.Format("2006-01-02 15:04")))
+	statsBuilder.WriteString("  " + trf("Author: %s", truncateMessage(currentCommit.Author, statsColumnWidth-10)) + "\n")
+	statsBuilder.WriteString("  " + trf("Date: %s", formatCommitDate(currentCommit, "2006-01-02 15:04")) + "\n")
+	if m.notice != "" {
+		statsBuilder.WriteString(statsValueStyle.UnsetWidth().Render("  " + m.notice))
+	} else if len(m.skippedCommits) > 0 {
+		statsBuilder.WriteString(warningStyle.Render("  " + trf("%d commits skipped (errors)", len(m.skippedCommits))))
+	}
 	statsBuilder.WriteString("\n")
 	statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
-		statsLabelStyle.Render("Commits:"),
+		statsLabelStyle.Render(tr("Commits:")),
 		statsValueStyle.Render(fmt.Sprintf("%d", m.currentCommitIndex+1))))
 	statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
-		statsLabelStyle.Render("Authors:"),
+		statsLabelStyle.Render(tr("Authors:")),
 		statsValueStyle.Render(fmt.Sprintf("%d", len(authorSet)))))
-
 	statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
-		statsLabelStyle.Render("Additions:"),
-		statsValueStyle.Render(fmt.Sprintf("+%d", currentCommit.CumulativeAdditions))))
+		statsLabelStyle.Render(tr("Merges:")),
+		statsValueStyle.Render(fmt.Sprintf("%d", mergeCount))))
 	statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
-		statsLabelStyle.Render("Deletions:"),
-		statsValueStyle.Render(fmt.Sprintf("-%d", currentCommit.CumulativeDeletions))))
+		statsLabelStyle.Render(tr("Speed:")),
+		statsValueStyle.Render(m.speedLabel())))
 
-	statsPanelHeight := 8
-	changesPanelHeight := m.height*2/3 - 10
-	timelinePanelHeight := m.height - statsPanelHeight - changesPanelHeight
-	if timelinePanelHeight < 8 {
-		timelinePanelHeight = 8
-		changesPanelHeight = m.height - statsPanelHeight - timelinePanelHeight
+	addLabel, delLabel := "Additions:", "Deletions:"
+	additions, deletions := currentCommit.CumulativeAdditions, currentCommit.CumulativeDeletions
+	if m.statsPerCommit {
+		addLabel, delLabel = "Commit adds:", "Commit dels:"
+		additions, deletions = currentCommit.Additions, currentCommit.Deletions
+	}
+	statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
+		statsLabelStyle.Render(tr(addLabel)),
+		statsValueStyle.Render(fmt.Sprintf("+%d", additions))))
+	statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
+		statsLabelStyle.Render(tr(delLabel)),
+		statsValueStyle.Render(fmt.Sprintf("-%d", deletions))))
+	if m.config.LOCEvery > 0 {
+		loc := fmt.Sprintf("%d", currentCommit.LOC)
+		if !currentCommit.LOCSampled {
+			loc = "~" + loc
+		}
+		statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
+			statsLabelStyle.Render(tr("Lines:")),
+			statsValueStyle.Render(loc)))
+	}
+	statsBuilder.WriteString(m.renderRangeSummary())
+	if len(m.config.AlertPaths) > 0 {
+		statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
+			statsLabelStyle.Render(tr("Alerts:")),
+			alertStyle.Render(fmt.Sprintf("%d", alertCount))))
+	}
+	if m.config.DedupeCommits {
+		statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
+			statsLabelStyle.Render(tr("Duplicates:")),
+			statsValueStyle.Render(fmt.Sprintf("%d", duplicateCount))))
+	}
+	if m.config.IgnoreNoise {
+		statsBuilder.WriteString(fmt.Sprintf("%s%s\n",
+			statsLabelStyle.Render(tr("Noise:")),
+			statsValueStyle.Render(fmt.Sprintf("+%d -%d", noiseAdditions, noiseDeletions))))
+	}
+
+	note := m.annotation(currentCommit.Hash)
+	statsPanelHeight := max(8, strings.Count(statsBuilder.String(), "\n")+1)
+	if note != "" {
+		statsPanelHeight++
+	}
+	changesPanelHeight, timelinePanelHeight := m.panelHeights(statsPanelHeight)
+	if m.compact {
+		statsPanelHeight = m.compactPanelHeight()
+		changesPanelHeight, timelinePanelHeight = statsPanelHeight, statsPanelHeight
+	}
+
+	statsContent := statsBuilder.String()
+	if fileListWidth := m.columnWidth() - 6 - statsColumnWidth; fileListWidth >= 20 {
+		statsContent = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(statsColumnWidth).Render(statsContent),
+			m.renderFileList(currentCommit, fileListWidth, statsPanelHeight-1))
+	}
+	if note != "" {
+		banner := "* " + note
+		if m.tourPaused {
+			banner += "  (press any key to continue)"
+		}
+		statsContent = annotationStyle.Render(truncateMessage(banner, m.columnWidth()-6)) + "\n" + statsContent
 	}
 
 	barChartContent := m.renderTimeline(timelinePanelHeight - 3)
-	brailleGraphContent := m.renderBrailleGraph(changesPanelHeight - 3)
+	timelineTitle := m.panelTitle(panelTimeline)
+	if m.config.ShowIndex {
+		timelineTitle += fmt.Sprintf(" %d/%d", m.currentCommitIndex+1, len(m.commits))
+	}
+	changesTitle, changesContent := m.panelTitle(panelChanges), ""
+	if m.showDirChart {
+		changesTitle = m.panelTitle(panelDirs)
+		changesContent = m.renderDirChart(m.columnWidth()-6, changesPanelHeight-3)
+	} else {
+		changesContent = m.renderBrailleGraph(changesPanelHeight - 3)
+		switch m.graphMode {
+		case graphModeProportional:
+			changesTitle += " (%)"
+		case graphModeSize:
+			changesTitle += " (LOC)"
+		case graphModeCadence:
+			changesTitle += " (churn + cadence)"
+		}
+	}
+
+	if m.compact {
+		return m.newView(m.renderCompact(statsContent, changesTitle, changesContent, timelineTitle, barChartContent))
+	}
 
 	leftColumn := lipgloss.JoinVertical(lipgloss.Left,
-		m.renderPanelWithHeader("Commit & Project Stats", statsBuilder.String(), m.width/2-2, statsPanelHeight),
-		m.renderPanelWithHeader("Commit Changes", brailleGraphContent, m.width/2-2, changesPanelHeight),
-		m.renderPanelWithHeader("Commit Timeline", barChartContent, m.width/2-2, timelinePanelHeight),
+		m.renderPanelWithHeader(m.panelTitle(panelStats), statsContent, m.columnWidth()-2, statsPanelHeight),
+		m.renderPanelWithHeader(changesTitle, changesContent, m.columnWidth()-2, changesPanelHeight),
+		m.renderPanelWithHeader(timelineTitle, barChartContent, m.columnWidth()-2, timelinePanelHeight),
 	)
 
-	rightColumn := m.renderPanelWithHeader("Developer Stats", m.renderDeveloperStats(), m.width/2-2, m.height)
+	var rightColumn string
+	if m.showLifetimes {
+		rightColumn = m.renderPanelWithHeader(m.panelTitle(panelLifetimes), m.renderLongestLived(m.columnWidth()-6, m.height-3), m.columnWidth()-2, m.height)
+	} else {
+		rightColumn = m.renderPanelWithHeader(m.panelTitle(panelDevelopers), m.renderDeveloperStats(), m.columnWidth()-2, m.height)
+	}
 
 	return m.newView(lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, rightColumn))
 }
 
+// statsColumnWidth is the width of the project stats beside the changed-file list.
+const statsColumnWidth = 32
+
+// renderFileList lists the files changed by a commit with their +/- counts,
+// truncated to rows lines.
+func (m *Model) renderFileList(c *commitInfo, width, rows int) string {
+	if m.fileStatsFailed[c.Hash] {
+		return graphAxisStyle.Render(tr("Files unavailable"))
+	}
+	if !c.FileStatsLoaded {
+		return graphAxisStyle.Render(tr("Loading files..."))
+	}
+	stats := c.FileStats
+	if len(stats) == 0 {
+		return graphAxisStyle.Render(tr("No files changed"))
+	}
+
+	shown := stats
+	if len(stats) > rows {
+		shown = stats[:max(0, rows-1)]
+	}
+
+	previous := m.previousFileSet(c)
+	var b strings.Builder
+	for _, s := range shown {
+		counts := additionStyle.Render("+"+formatStat(s.Additions)) + " " + deletionStyle.Render("-"+formatStat(s.Deletions))
+		nameWidth := width - lipgloss.Width(counts) - 2
+		name := truncatePath(s.Name, nameWidth)
+		if previous != nil && !previous[s.Name] {
+			// The focus moved here: the previous commit didn't touch this file.
+			b.WriteString(warningStyle.Render("•") + graphHighlight.Render(name) + " " + counts + "\n")
+			continue
+		}
+		b.WriteString(" " + name + " " + counts + "\n")
+	}
+	if len(shown) < len(stats) {
+		b.WriteString(graphAxisStyle.Render(trf("+%d more", len(stats)-len(shown))) + "\n")
+	}
+	return b.String()
+}
+
+// previousFileSet returns the files changed by the commit before c in the
+// timeline, or nil when there is none to compare with or its files haven't
+// been loaded yet.
+func (m *Model) previousFileSet(c *commitInfo) map[string]bool {
+	i := m.currentCommitIndex
+	if i <= 0 || i >= len(m.commits) || m.commits[i] != c || !m.commits[i-1].FileStatsLoaded {
+		return nil
+	}
+	stats := m.commits[i-1].FileStats
+	set := make(map[string]bool, len(stats))
+	for _, s := range stats {
+		set[s.Name] = true
+	}
+	return set
+}
+
 func (m *Model) renderTimeline(timelineHeight int) string {
 	if len(m.commits) == 0 {
-		return "No commits"
+		return tr("No commits")
 	}
 	if timelineHeight <= 0 {
-		return "Not enough space"
+		return tr("Not enough space")
 	}
 
 	// Try to center the current commit index
@@ -1205,22 +2419,60 @@ func (m *Model) renderTimeline(timelineHeight int) string {
 	if visibleEnd > len(m.commits) {
 		visibleEnd = len(m.commits)
 	}
+	// A single row has no room for a separator above the current commit.
+	daySeparators := m.showDaySeparators && timelineHeight >= 2
+	if daySeparators {
+		visibleStart, visibleEnd = m.daySeparatedWindow(timelineHeight)
+	}
 
 	barChartContent := strings.Builder{}
 
-	labelWidth := 8
-	statsWidth := 15
+	labelWidth := len(m.shortHash(m.commits[0].Hash)) + 1 // Room for the ! or * marker
+	statsWidth := 17
 	padding := 2
-	availableWidth := m.width/2 - 6
+	availableWidth := m.columnWidth() - 6
 	msgWidth := availableWidth - labelWidth - statsWidth - padding
-	if msgWidth < 20 {
-		msgWidth = 20
+	marker := m.config.Highlight.Marker
+	markerWidth := lipgloss.Width(marker)
+	if markerWidth > 0 {
+		msgWidth -= markerWidth + 1
+	}
+
+	var graphRows []string
+	if m.showGraph {
+		graphRows = layoutDAG(m.commits, visibleStart, visibleEnd)
+		if len(graphRows) > 0 {
+			msgWidth -= lipgloss.Width(graphRows[0]) + 1
+		}
+	}
+	// The index column is dropped rather than squeezing messages below the minimum.
+	indexDigits := 0
+	if m.config.ShowIndex {
+		digits := len(fmt.Sprint(len(m.commits)))
+		if msgWidth-(digits+2) >= 20 {
+			indexDigits = digits
+			msgWidth -= digits + 2
+		}
+	}
+	// Longer hashes eat into the minimum rather than pushing rows past the panel.
+	if minMsgWidth := max(20-(labelWidth-8), 3); msgWidth < minMsgWidth {
+		msgWidth = minMsgWidth
 	}
 
 	for i := visibleStart; i < visibleEnd; i++ {
 		c := m.commits[i]
+		if daySeparators && (i == visibleStart || m.startsDay(i)) {
+			barChartContent.WriteString(renderDaySeparator(c, availableWidth) + "\n")
+		}
 
-		label := barLabelStyle.Render(c.Hash[:7])
+		hash := m.shortHash(c.Hash)
+		labelStyle := barLabelStyle.Width(labelWidth)
+		label := labelStyle.Render(hash)
+		if len(c.AlertPaths) > 0 {
+			label = labelStyle.Inherit(alertStyle).Render("!" + hash)
+		} else if m.annotation(c.Hash) != "" {
+			label = labelStyle.Inherit(annotationStyle).Render("*" + hash)
+		}
 
 		var stats string
 		addFormatted := "+" + formatStat(c.Additions)
@@ -1229,16 +2481,51 @@ func (m *Model) renderTimeline(timelineHeight int) string {
 		delStr := lipgloss.NewStyle().Width(7).Align(lipgloss.Left).Render(deletionStyle.Render(delFormatted))
 		stats = lipgloss.JoinHorizontal(lipgloss.Left, addStr, " ", delStr)
 
-		msg := truncateMessage(c.Message, msgWidth)
+		tag := m.tagLabel(c.Hash, msgWidth/3)
+		msg := truncateMessage(m.displaySubject(c.Message), max(msgWidth-lipgloss.Width(tag), 4))
 		if i == m.currentCommitIndex {
-			msg = lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Bold(true).Render(msg)
+			msg = graphHighlight.Render(msg)
 		} else {
 			msg = barMessageStyle.Render(msg)
 		}
+		if tag != "" {
+			msg = tagStyle.Render(tag) + msg
+		}
+
+		glyph := unsignedGlyphStyle.Render("·")
+		if c.Signed {
+			glyph = signedGlyphStyle.Render("✓")
+		}
+		if c.ParentCount > 1 {
+			glyph = mergeGlyphStyle.Render("⑂")
+		}
+		if c.DuplicateOf != "" {
+			glyph = unsignedGlyphStyle.Render("=")
+		}
+		switch c.Hash {
+		case m.markA:
+			glyph = warningStyle.Render("A")
+		case m.markB:
+			glyph = warningStyle.Render("B")
+		}
 
-		line := fmt.Sprintf("%s %s %s", label, stats, msg)
+		if graphRows != nil {
+			glyph += " " + dagStyle.Render(graphRows[i-visibleStart])
+		}
+
+		line := fmt.Sprintf("%s %s %s %s", label, stats, glyph, msg)
+		if indexDigits > 0 {
+			line = graphAxisStyle.Render(fmt.Sprintf(" #%*d", indexDigits, i+1)) + line
+		}
 		if i == m.currentCommitIndex {
-			line = barHighlightStyle.Render(line)
+			line = m.highlightStyle.Render(line)
+		}
+		if markerWidth > 0 {
+			prefix := strings.Repeat(" ", markerWidth)
+			if i == m.currentCommitIndex {
+				prefix = graphHighlight.Render(marker)
+			}
+			line = prefix + " " + line
 		}
 		barChartContent.WriteString(line + "\n")
 	}
@@ -1247,76 +2534,96 @@ func (m *Model) renderTimeline(timelineHeight int) string {
 }
 
 func (m *Model) renderDeveloperStats() string {
-	// First, get the list of available years for the cycle control
-	yearSet := make(map[int]struct{})
-	for i := 0; i <= m.currentCommitIndex; i++ {
-		yearSet[m.commits[i].Date.Year()] = struct{}{}
-	}
-	years := make([]int, 0, len(yearSet))
-	for year := range yearSet {
-		years = append(years, year)
+	if len(m.comparedAuthors) == 2 {
+		return m.renderAuthorComparison()
 	}
-	sort.Sort(sort.Reverse(sort.IntSlice(years)))
-	m.availableStatYears = append([]int{0}, years...) // 0 for All-Time
 
 	// --- Data Aggregation ---
-	// Determine which commits to analyze based on the selected year
-	var commitsToAnalyze []*commitInfo
-	if m.displayedStatsYear == 0 { // All-Time
-		commitsToAnalyze = m.commits[:m.currentCommitIndex+1]
-	} else {
-		for i := 0; i <= m.currentCommitIndex; i++ {
-			if m.commits[i].Date.Year() == m.displayedStatsYear {
-				commitsToAnalyze = append(commitsToAnalyze, m.commits[i])
-			}
-		}
-	}
-
-	authorChurn := make(map[string]int)
-	weekdayCounts := make(map[time.Weekday]int)
-	monthCounts := make(map[time.Month]int)
-	hourCounts := make(map[int]int)
-
-	for _, c := range commitsToAnalyze {
-		authorChurn[c.Author] += c.Churn
-		weekdayCounts[c.Date.Weekday()]++
-		monthCounts[c.Date.Month()]++
-		hourCounts[c.Date.Local().Hour()]++
-	}
+	stats := m.developerStats()
+	unknownDates := stats.unknownDates
+	monthCounts, weekdayCounts, hourCounts := stats.months, stats.weekdays, stats.hours
 
 	// Determine top contributors from the analyzed commits
-	topContributors := make([]authorStat, 0, len(authorChurn))
-	for name, churn := range authorChurn {
-		topContributors = append(topContributors, authorStat{name: name, churn: churn})
-	}
-	sort.Slice(topContributors, func(i, j int) bool {
-		return topContributors[i].churn > topContributors[j].churn
-	})
+	top := m.topContributors()
+	focused := m.focusedAuthor(stats.authors)
 
 	// --- Rendering ---
 	var headerText string
 	if m.displayedStatsYear == 0 {
-		headerText = "Top 5 (All-Time)"
+		headerText = tr("Top 5 (All-Time)")
 	} else {
-		headerText = fmt.Sprintf("Top 5 (%d)", m.displayedStatsYear)
+		headerText = trf("Top 5 (%d)", m.displayedStatsYear)
+	}
+	headerText += trf(" by %s", tr(contributorSortNames[m.contributorSort]))
+	if m.byCommitter {
+		headerText += tr(" (committers)")
 	}
 
 	var b strings.Builder
 
-	availableWidth := m.width/2 - 8
+	availableWidth := m.columnWidth() - 8
 	barChartWidth := availableWidth - 20
 	if barChartWidth < 10 {
 		barChartWidth = 10
 	}
 
-	b.WriteString(headerStyle.Render(headerText))
-	b.WriteString("\n")
-	for i := 0; i < len(topContributors) && i < 5; i++ {
-		b.WriteString(fmt.Sprintf(" %-18s %d\n", truncateMessage(topContributors[i].name, 32), topContributors[i].churn))
+	if focused != nil {
+		b.WriteString(m.renderFocusedAuthor(focused, stats, barChartWidth))
+		top = nil
+	} else {
+		b.WriteString(headerStyle.Render(headerText))
+		b.WriteString("\n")
+		if m.config.FocusAuthor != "" {
+			b.WriteString(graphAxisStyle.Render(" " + trf("No commits by %s yet", m.config.FocusAuthor)))
+			b.WriteString("\n")
+		}
+	}
+	extraColumn := ""
+	switch m.contributorSort {
+	case sortByAdditions, sortByDeletions, sortByRecent:
+		extraColumn = tr(contributorSortNames[m.contributorSort])
+	}
+	if focused == nil {
+		b.WriteString(graphAxisStyle.Render(fmt.Sprintf(" %-18s %-8s %-8s %s", "", tr("churn"), tr("commits"), extraColumn)))
+		b.WriteString("\n")
+	}
+	cursor := min(m.authorCursor, len(top)-1)
+	for i, a := range top {
+		extra := ""
+		switch m.contributorSort {
+		case sortByAdditions:
+			extra = fmt.Sprintf("+%d", a.additions)
+		case sortByDeletions:
+			extra = fmt.Sprintf("-%d", a.deletions)
+		case sortByRecent:
+			extra = unknownDateLabel
+			if !a.last.IsZero() {
+				extra = a.last.Format("2006-01-02")
+			}
+		}
+		b.WriteString(m.authorRowPrefix(a.key, m.authorCursorOn && i == cursor))
+		b.WriteString(fmt.Sprintf("%-18s %-8d %-8d %s\n", truncateMessage(a.name, 32), a.churn, a.commits, extra))
+	}
+	if unknownDates > 0 {
+		b.WriteString(graphAxisStyle.Render(fmt.Sprintf(" %s: %d commits, not in the charts below", unknownDateLabel, unknownDates)))
+		b.WriteString("\n")
 	}
 	b.WriteString("\n")
 
-	b.WriteString(headerStyle.Render("Commits by Month"))
+	if busy := busyDays(stats.daily, m.config.BusyDayThreshold); len(busy) > 0 {
+		b.WriteString(headerStyle.Render(trf("Busy Days (>%d commits)", m.config.BusyDayThreshold)))
+		b.WriteString("\n")
+		for i := 0; i < len(busy) && i < 5; i++ {
+			b.WriteString(fmt.Sprintf(" %-12s %-18s %s\n", busy[i].day, truncateMessage(busy[i].author, 18), warningStyle.Render(fmt.Sprintf("%d", busy[i].commits))))
+		}
+		if len(busy) > 5 {
+			b.WriteString(graphAxisStyle.Render(" " + trf("... and %d more", len(busy)-5)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(headerStyle.Render(tr("Commits by Month")))
 	b.WriteString("\n")
 	months := []time.Month{time.January, time.February, time.March, time.April, time.May, time.June, time.July, time.August, time.September, time.October, time.November, time.December}
 	maxMonthCount := 0
@@ -1332,11 +2639,11 @@ func (m *Model) renderDeveloperStats() string {
 		count := monthCounts[month]
 		barLength := (count * barChartWidth) / maxMonthCount
 		bar := strings.Repeat(barChar, barLength)
-		b.WriteString(fmt.Sprintf(" %-12s |%s %-5d\n", month.String(), barStyle.Render(bar), count))
+		b.WriteString(fmt.Sprintf(" %-12s |%s %-5d\n", monthName(month), barStyle.Render(bar), count))
 	}
 	b.WriteString("\n")
 
-	b.WriteString(headerStyle.Render("Commits by Weekday"))
+	b.WriteString(headerStyle.Render(tr("Commits by Weekday")))
 	b.WriteString("\n")
 	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
 	maxWeekdayCount := 0
@@ -1352,11 +2659,11 @@ func (m *Model) renderDeveloperStats() string {
 		count := weekdayCounts[day]
 		barLength := (count * barChartWidth) / maxWeekdayCount
 		bar := strings.Repeat(barChar, barLength)
-		b.WriteString(fmt.Sprintf(" %-12s |%s %-5d\n", day.String(), barStyle.Render(bar), count))
+		b.WriteString(fmt.Sprintf(" %-12s |%s %-5d\n", weekdayName(day), barStyle.Render(bar), count))
 	}
 	b.WriteString("\n")
 
-	b.WriteString(headerStyle.Render("Commits by Hour (Local)"))
+	b.WriteString(headerStyle.Render(tr("Commits by Hour (Local)")))
 	b.WriteString("\n")
 	maxHourCount := 0
 	for i := 0; i < 24; i++ {
@@ -1388,6 +2695,15 @@ func truncateMessage(msg string, maxLen int) string {
 	return result
 }
 
+// truncatePath shortens a path from the left so the file name stays visible.
+func truncatePath(p string, maxLen int) string {
+	r := []rune(p)
+	if maxLen <= 1 || len(r) <= maxLen {
+		return fmt.Sprintf("%-*s", max(0, maxLen), p)
+	}
+	return "…" + string(r[len(r)-maxLen+1:])
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
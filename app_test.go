@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestRecomputeMaxima(t *testing.T) {
+	commit := func(additions, deletions int, parents ...string) *commitInfo {
+		return &commitInfo{Additions: additions, Deletions: deletions, ParentHashes: parents}
+	}
+	history := []*commitInfo{
+		commit(500, 0),
+		commit(10, 4, "a"),
+		commit(90, 70, "b"),
+		commit(20, 5, "c"),
+	}
+	noisy := commit(80, 60, "d")
+	noisy.NoiseAdditions, noisy.NoiseDeletions = 75, 58
+
+	tests := []struct {
+		name          string
+		initialCommit string
+		commits       []*commitInfo
+		wantAdditions int
+		wantDeletions int
+	}{
+		{"full history", initialCommitInclude, history, 500, 70},
+		{"largest commit removed", initialCommitInclude, history[1:], 90, 70},
+		{"rewound before the largest change", initialCommitExclude, history[:2], 10, 4},
+		{"root commit left out of the scale", initialCommitExclude, history, 90, 70},
+		{"noise discounted", initialCommitExclude, []*commitInfo{history[1], noisy}, 10, 4},
+		{"no commits", initialCommitInclude, nil, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Model{config: Config{InitialCommit: tt.initialCommit}, maxAdditions: 1000, maxDeletions: 1000}
+			m.commits = tt.commits
+			m.recomputeMaxima()
+			if m.maxAdditions != tt.wantAdditions || m.maxDeletions != tt.wantDeletions {
+				t.Errorf("maxima = %d/%d, want %d/%d", m.maxAdditions, m.maxDeletions, tt.wantAdditions, tt.wantDeletions)
+			}
+		})
+	}
+}
+
+// largeDiffModel returns a model in the diff view of a single commit whose
+// diff has files hunks of lines lines each, already loaded as it would be.
+func largeDiffModel(tb testing.TB, files, lines int) *Model {
+	tb.Helper()
+	var b strings.Builder
+	for f := range files {
+		fmt.Fprintf(&b, "diff --git a/f%d.go b/f%d.go\n--- a/f%d.go\n+++ b/f%d.go\n@@ -1,%d +1,%d @@\n", f, f, f, f, lines, lines)
+		for l := range lines {
+			fmt.Fprintf(&b, "-\told := %d // a line long enough to be realistic\n+\tnew := %d // a line long enough to be realistic\n", l, l)
+		}
+	}
+	diff := b.String()
+
+	cfg := fixtureConfig(tb, "")
+	cfg.Width, cfg.Height = 120, 50
+	m := InitialModel(cfg)
+	m.appendCommit(&commitInfo{
+		Hash:        strings.Repeat("a", 40),
+		Message:     "Rewrite everything",
+		Author:      "Alice",
+		Date:        time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		DiffContent: diff,
+		DiffLines:   strings.Split(diff, "\n"),
+	})
+	m.loadingComplete = true
+	m.diffState = inDiffView
+	m.loadCurrentDiff()
+	return &m
+}
+
+// BenchmarkRenderDiffView renders a screenful of a large diff from the lines
+// split at load time, and, for comparison, re-splitting the diff every frame
+// as the view used to.
+func BenchmarkRenderDiffView(b *testing.B) {
+	m := largeDiffModel(b, 200, 250)
+	b.Run("cached lines", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; b.Loop(); i++ {
+			m.diffScroll = i * 7 % len(m.currentDiffLines)
+			m.renderDiffView()
+		}
+	})
+	b.Run("split per frame", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; b.Loop(); i++ {
+			m.currentDiffLines = strings.Split(m.currentDiff, "\n")
+			m.diffScroll = i * 7 % len(m.currentDiffLines)
+			m.renderDiffView()
+		}
+	})
+}
+
+// BenchmarkDiffScroll presses down in the diff view and renders each frame,
+// against reloading the diff, and so splitting it again, on every keystroke.
+func BenchmarkDiffScroll(b *testing.B) {
+	down := tea.KeyPressMsg{Code: 'j', Text: "j"}
+	b.Run("cached lines", func(b *testing.B) {
+		m := largeDiffModel(b, 200, 250)
+		b.ReportAllocs()
+		for b.Loop() {
+			m.Update(down)
+			m.View()
+		}
+	})
+	b.Run("reload per keystroke", func(b *testing.B) {
+		m := largeDiffModel(b, 200, 250)
+		c := m.commits[m.currentCommitIndex]
+		b.ReportAllocs()
+		for b.Loop() {
+			m.Update(down)
+			c.DiffLines = nil
+			m.loadCurrentDiff()
+			m.View()
+		}
+	})
+}
+
+func TestCompactLayoutDefault(t *testing.T) {
+	for _, tt := range []struct {
+		width       int
+		wantCompact bool
+	}{{79, true}, {80, false}, {120, false}} {
+		m := InitialModel(fixtureConfig(t, ""))
+		m.resize(tt.width, 40)
+		if m.compact != tt.wantCompact {
+			t.Errorf("%d columns: compact = %v, want %v", tt.width, m.compact, tt.wantCompact)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+)
+
+// sparkLevels are the bar heights of a sparkline, lowest first.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// statsCommits returns the commits up to the current one that fall in the
+// selected stats year, or all of them for All-Time.
+func (m *Model) statsCommits() []*commitInfo {
+	return commitsInYear(m.commits[:m.currentCommitIndex+1], m.displayedStatsYear)
+}
+
+// topAuthors is the contributor list as the developer stats panel shows it,
+// empty while a focused author takes its place.
+func (m *Model) topAuthors() []authorStat {
+	if m.focusedAuthor(m.developerStats().authors) != nil {
+		return nil
+	}
+	return m.topContributors()
+}
+
+// moveAuthorCursor shows the cursor in the contributor list on first use and
+// moves it a row down or up after that.
+func (m *Model) moveAuthorCursor(down bool) {
+	n := len(m.topAuthors())
+	if n == 0 || len(m.comparedAuthors) == 2 {
+		return
+	}
+	switch {
+	case !m.authorCursorOn:
+		m.authorCursorOn = true
+		m.authorCursor = 0
+		if !down {
+			m.authorCursor = n - 1
+		}
+	case down:
+		m.authorCursor = min(m.authorCursor+1, n-1)
+	default:
+		m.authorCursor = max(m.authorCursor-1, 0)
+	}
+}
+
+// pickAuthor toggles the author under the cursor for comparison. Once two are
+// picked the panel compares them; picking again goes back to the list.
+func (m *Model) pickAuthor() {
+	if len(m.comparedAuthors) == 2 {
+		m.comparedAuthors = nil
+		return
+	}
+	top := m.topAuthors()
+	if !m.authorCursorOn || len(top) == 0 {
+		m.notice = "J/K: choose author"
+		return
+	}
+	pick := top[min(m.authorCursor, len(top)-1)]
+	for i, a := range m.comparedAuthors {
+		if a.key == pick.key {
+			m.comparedAuthors = append(m.comparedAuthors[:i], m.comparedAuthors[i+1:]...)
+			return
+		}
+	}
+	m.comparedAuthors = append(m.comparedAuthors, authorStat{key: pick.key, name: pick.name})
+}
+
+// authorRowPrefix marks the cursor and picked authors in the contributor list.
+func (m *Model) authorRowPrefix(key string, cursor bool) string {
+	prefix := " "
+	if cursor {
+		prefix = "›"
+	}
+	for _, a := range m.comparedAuthors {
+		if a.key == key {
+			if !cursor {
+				prefix = "•"
+			}
+			return warningStyle.Render(prefix)
+		}
+	}
+	return prefix
+}
+
+// authorActivity is what the comparison shows of one author's commits.
+type authorActivity struct {
+	stat     *authorStat // nil without commits in the period
+	cadence  []int       // Commits per slice of the period
+	weekdays [7]int      // Monday first
+	hours    [24]int     // Local time
+}
+
+// renderAuthorComparison shows the two picked authors side by side. Cadence,
+// weekday and hour sparklines share a scale across both columns so their
+// heights compare directly.
+func (m *Model) renderAuthorComparison() string {
+	width := m.columnWidth() - 8
+	colWidth := max((width-3)/2, 12)
+	commits := m.statsCommits()
+	from, to := m.statsPeriod(commits)
+
+	grouping := m.authorGrouping()
+	authors := make(map[string]*authorStat)
+	activity := make(map[string]*authorActivity, 2)
+	for _, a := range m.comparedAuthors {
+		activity[a.key] = &authorActivity{cadence: make([]int, colWidth)}
+	}
+	for _, c := range commits {
+		a := grouping.tally(authors, c)
+		act := activity[a.key]
+		if act == nil || hasUnknownDate(c) {
+			continue
+		}
+		act.cadence[cadenceSlot(c.Date, from, to, colWidth)]++
+		act.weekdays[(c.Date.Weekday()+6)%7]++
+		act.hours[c.Date.Local().Hour()]++
+	}
+
+	var maxCadence, maxWeekday, maxHour int
+	for key, act := range activity {
+		act.stat = authors[key]
+		for _, n := range act.cadence {
+			maxCadence = max(maxCadence, n)
+		}
+		for _, n := range act.weekdays {
+			maxWeekday = max(maxWeekday, n)
+		}
+		for _, n := range act.hours {
+			maxHour = max(maxHour, n)
+		}
+	}
+
+	columns := make([]string, 0, 2)
+	for _, picked := range m.comparedAuthors {
+		act := activity[picked.key]
+		var b strings.Builder
+		if act.stat == nil {
+			b.WriteString(graphHighlight.Render(truncateMessage(picked.name, colWidth)) + "\n")
+			if m.displayedStatsYear != 0 {
+				b.WriteString(graphAxisStyle.Render(trf("No commits in %d", m.displayedStatsYear)))
+			} else {
+				b.WriteString(graphAxisStyle.Render(tr("No commits yet")))
+			}
+			columns = append(columns, lipgloss.NewStyle().Width(colWidth).Render(b.String()))
+			continue
+		}
+		a := act.stat
+		last := unknownDateLabel
+		if !a.last.IsZero() {
+			last = a.last.Format("2006-01-02")
+		}
+		b.WriteString(graphHighlight.Render(truncateMessage(a.name, colWidth)) + "\n")
+		b.WriteString(fmt.Sprintf("%-12s %d\n", tr("churn"), a.churn))
+		b.WriteString(fmt.Sprintf("%-12s %d\n", tr("commits"), a.commits))
+		b.WriteString(fmt.Sprintf("%-12s %s\n", tr("additions"), additionStyle.Render(fmt.Sprintf("+%d", a.additions))))
+		b.WriteString(fmt.Sprintf("%-12s %s\n", tr("deletions"), deletionStyle.Render(fmt.Sprintf("-%d", a.deletions))))
+		b.WriteString(fmt.Sprintf("%-12s %s\n\n", tr("last commit"), last))
+		b.WriteString(graphAxisStyle.Render(tr("Cadence")) + "\n")
+		b.WriteString(barStyle.Render(sparkline(act.cadence, maxCadence)) + "\n\n")
+		b.WriteString(graphAxisStyle.Render(tr("By weekday")) + "\n")
+		b.WriteString(barStyle.Render(sparkline(act.weekdays[:], maxWeekday)) + "\n")
+		b.WriteString(graphAxisStyle.Render(weekdayInitials()) + "\n\n")
+		b.WriteString(graphAxisStyle.Render(tr("By hour (local)")) + "\n")
+		b.WriteString(barStyle.Render(sparkline(act.hours[:], maxHour)) + "\n")
+		b.WriteString(graphAxisStyle.Render("0     6     12    18") + "\n")
+		columns = append(columns, lipgloss.NewStyle().Width(colWidth).Render(b.String()))
+	}
+
+	header := tr("Compare (All-Time)")
+	if m.displayedStatsYear != 0 {
+		header = trf("Compare (%d)", m.displayedStatsYear)
+	}
+	return headerStyle.Render(header) + "\n" +
+		graphAxisStyle.Render(" x: back to the list") + "\n\n" +
+		lipgloss.JoinHorizontal(lipgloss.Top, " ", columns[0], "  ", columns[1])
+}
+
+// statsPeriod is the span cadence sparklines cover: the selected year, or the
+// history so far for All-Time.
+func (m *Model) statsPeriod(commits []*commitInfo) (from, to time.Time) {
+	if m.displayedStatsYear != 0 {
+		from = time.Date(m.displayedStatsYear, time.January, 1, 0, 0, 0, 0, time.Local)
+		return from, from.AddDate(1, 0, 0)
+	}
+	for _, c := range commits {
+		if hasUnknownDate(c) {
+			continue
+		}
+		if from.IsZero() || c.Date.Before(from) {
+			from = c.Date
+		}
+		if c.Date.After(to) {
+			to = c.Date
+		}
+	}
+	return from, to
+}
+
+// cadenceSlot is the sparkline slot of width slots that date falls in.
+func cadenceSlot(date, from, to time.Time, width int) int {
+	span := to.Sub(from)
+	if span <= 0 {
+		return 0
+	}
+	return max(min(int(int64(date.Sub(from))*int64(width)/int64(span)), width-1), 0)
+}
+
+// sparkline draws counts as one bar each, scaled so maxCount is full height.
+// Empty slots stay blank to set them apart from small counts.
+func sparkline(counts []int, maxCount int) string {
+	var b strings.Builder
+	for _, n := range counts {
+		if n <= 0 || maxCount <= 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(sparkLevels[min((n*len(sparkLevels)-1)/maxCount, len(sparkLevels)-1)])
+	}
+	return b.String()
+}
+
+// weekdayInitials labels a Monday-first weekday sparkline.
+func weekdayInitials() string {
+	var b strings.Builder
+	for i := 0; i < 7; i++ {
+		b.WriteString(string([]rune(weekdayName(time.Weekday((i + 1) % 7)))[:1]))
+	}
+	return b.String()
+}
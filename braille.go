@@ -4,6 +4,9 @@ import (
 	"bytes"
 )
 
+// brailleBlank is the braille character with no dots set.
+const brailleBlank = '\u2800'
+
 // BrailleCanvas represents a canvas for drawing with braille characters.
 type BrailleCanvas struct {
 	Width  int
@@ -28,13 +31,26 @@ func (c *BrailleCanvas) Set(x, y int) {
 	c.buffer[y*c.Width+x] = true
 }
 
+// Unset clears a pixel on the canvas.
+func (c *BrailleCanvas) Unset(x, y int) {
+	if x < 0 || x >= c.Width || y < 0 || y >= c.Height {
+		return
+	}
+	c.buffer[y*c.Width+x] = false
+}
+
+// Clear clears every pixel, so the canvas can be drawn on again.
+func (c *BrailleCanvas) Clear() {
+	clear(c.buffer)
+}
+
 // String returns the canvas as a string of braille characters.
 func (c *BrailleCanvas) String() string {
 	var buf bytes.Buffer
 
 	for y := 0; y < c.Height; y += 4 {
 		for x := 0; x < c.Width; x += 2 {
-			r := 0x2800
+			r := int(brailleBlank)
 			if c.get(x, y) {
 				r |= 1
 			}
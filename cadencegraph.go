@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+)
+
+// cadenceWindow is how far back the cadence line counts commits.
+const cadenceWindow = 7 * 24 * time.Hour
+
+// Colors of the two cadence graph series.
+var (
+	cadenceChurnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+	cadenceLineStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+// recentCommits counts the commits in the week up to commit i. It walks back
+// through the timeline, which is in date order apart from clock skew.
+func (m *Model) recentCommits(i int) int {
+	c := m.commits[i]
+	if hasUnknownDate(c) {
+		return 0
+	}
+	n := 0
+	for j := i; j >= 0; j-- {
+		if hasUnknownDate(m.commits[j]) {
+			continue
+		}
+		if c.Date.Sub(m.commits[j].Date) > cadenceWindow {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// renderCadenceGraph draws each commit's churn as a bar from the bottom with
+// the commits per week as a line across them. Both are scaled to their own
+// maximum in the window, as the legend below the graph says.
+func (m *Model) renderCadenceGraph(graphHeight, startIndex, endIndex int) string {
+	height := (graphHeight - 1) * 4
+	bars := NewBrailleCanvas(m.graphColumns*2, height)
+	line := NewBrailleCanvas(m.graphColumns*2, height)
+
+	maxChurn, maxRate := 0, 0
+	rates := make([]int, endIndex-startIndex)
+	for i := startIndex; i < endIndex; i++ {
+		maxChurn = max(maxChurn, m.commits[i].Churn)
+		rates[i-startIndex] = m.recentCommits(i)
+		maxRate = max(maxRate, rates[i-startIndex])
+	}
+	logMaxChurn := math.Log1p(float64(maxChurn))
+	if logMaxChurn == 0 {
+		logMaxChurn = 1
+	}
+
+	prevY := -1
+	for i := startIndex; i < endIndex; i++ {
+		x := i - startIndex
+		if churn := m.commits[i].Churn; churn > 0 {
+			top := int(math.Log1p(float64(churn)) / logMaxChurn * float64(height-1))
+			for y := 0; y <= top; y++ {
+				bars.Set(x, height-1-y)
+			}
+		}
+		y := height - 1 - rates[x]*(height-1)/max(maxRate, 1)
+		// Fill the gap from the previous point so steep changes stay joined.
+		from, to := y, y
+		if prevY >= 0 {
+			from, to = min(y, prevY), max(y, prevY)
+		}
+		for yy := from; yy <= to; yy++ {
+			line.Set(x, yy)
+		}
+		prevY = y
+	}
+
+	var b strings.Builder
+	barRows := strings.Split(strings.TrimSuffix(bars.String(), "\n"), "\n")
+	lineRows := strings.Split(strings.TrimSuffix(line.String(), "\n"), "\n")
+	for row := range barRows {
+		barCells, lineCells := []rune(barRows[row]), []rune(lineRows[row])
+		for col, cell := range barCells {
+			// A cell holds one color, so where the line passes it wins and
+			// takes the bar's dots along.
+			if lineCells[col] != brailleBlank {
+				b.WriteString(cadenceLineStyle.Render(string(cell | lineCells[col])))
+			} else if cell != brailleBlank {
+				b.WriteString(cadenceChurnStyle.Render(string(cell)))
+			} else {
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(cadenceChurnStyle.Render("█") + graphAxisStyle.Render(fmt.Sprintf(" churn (log, max %d)  ", maxChurn)))
+	b.WriteString(cadenceLineStyle.Render("─") + graphAxisStyle.Render(fmt.Sprintf(" commits per week (max %d)", maxRate)))
+	return b.String()
+}
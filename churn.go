@@ -0,0 +1,26 @@
+package main
+
+// Churn modes, selected with the churnMode config.
+const (
+	churnSum       = "sum"       // additions + deletions
+	churnMax       = "max"       // the larger of additions and deletions, so a rewrite counts once
+	churnNet       = "net"       // |additions - deletions|, the change in line count
+	churnAdditions = "additions" // only lines added
+)
+
+// computeChurn derives a commit's churn from its line counts.
+func computeChurn(mode string, additions, deletions int) int {
+	switch mode {
+	case churnMax:
+		return max(additions, deletions)
+	case churnNet:
+		if additions > deletions {
+			return additions - deletions
+		}
+		return deletions - additions
+	case churnAdditions:
+		return additions
+	default:
+		return additions + deletions
+	}
+}
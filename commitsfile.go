@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// readCommitList loads the revisions listed in path, one per line, and resolves
+// them to full commit hashes in file order. Blank lines and lines starting with
+// # are ignored. Revisions that do not name a commit are returned in unknown.
+func readCommitList(repoPath, path string) (hashes, unknown []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read commits file: %v", err)
+	}
+	var revs []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		revs = append(revs, line)
+	}
+	return resolveCommits(repoPath, revs)
+}
+
+// resolveCommits resolves revisions to full commit hashes, keeping their order.
+// Revisions that do not name a readable commit are returned in unknown.
+func resolveCommits(repoPath string, revs []string) (hashes, unknown []string, err error) {
+	if len(revs) == 0 {
+		return nil, nil, nil
+	}
+
+	var input strings.Builder
+	for _, rev := range revs {
+		input.WriteString(rev + "^{commit}\n")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "cat-file", "--batch-check=%(objectname) %(objecttype)")
+	cmd.Stdin = strings.NewReader(input.String())
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("git cat-file failed: %v", err)
+	}
+
+	// cat-file answers each input line with exactly one output line.
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for i := 0; scanner.Scan() && i < len(revs); i++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == "commit" {
+			hashes = append(hashes, fields[0])
+		} else {
+			unknown = append(unknown, revs[i])
+		}
+	}
+	return hashes, unknown, nil
+}
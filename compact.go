@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Panels in the order the compact layout cycles through them. The developer
+// panel shows lifetimes instead when those are toggled on.
+var compactPanels = []string{panelStats, panelChanges, panelTimeline, panelDevelopers}
+
+// columnWidth is the width available to one column of panels: half the
+// screen normally, all of it in the compact layout.
+func (m *Model) columnWidth() int {
+	if m.compact {
+		return m.width
+	}
+	return m.width / 2
+}
+
+// cyclePanel moves the compact layout to the next (or previous) panel.
+func (m *Model) cyclePanel(delta int) {
+	m.compactPanel = (m.compactPanel + delta + len(compactPanels)) % len(compactPanels)
+}
+
+// renderTabs draws the compact layout's panel switcher. Inactive tabs fall
+// back to their numbers when the full titles don't fit.
+func (m *Model) renderTabs(titles []string) string {
+	render := func(short bool) string {
+		tabs := make([]string, len(titles))
+		for i, title := range titles {
+			switch {
+			case i == m.compactPanel:
+				tabs[i] = headerStyle.UnsetPadding().Render(fmt.Sprintf("%d %s", i+1, title))
+			case short:
+				tabs[i] = statsLabelStyle.UnsetWidth().Render(fmt.Sprintf("%d", i+1))
+			default:
+				tabs[i] = statsLabelStyle.UnsetWidth().Render(fmt.Sprintf("%d %s", i+1, title))
+			}
+		}
+		return " " + strings.Join(tabs, graphAxisStyle.Render(" │ "))
+	}
+	if bar := render(false); lipgloss.Width(bar) <= m.width {
+		return bar
+	}
+	return render(true)
+}
+
+// renderCompact shows the selected panel across the whole width with the tab
+// switcher above it.
+func (m *Model) renderCompact(statsContent, changesTitle, changesContent, timelineTitle, timelineContent string) string {
+	developersTitle := m.panelTitle(panelDevelopers)
+	if m.showLifetimes {
+		developersTitle = m.panelTitle(panelLifetimes)
+	}
+	titles := []string{m.panelTitle(panelStats), changesTitle, timelineTitle, developersTitle}
+
+	var content string
+	switch compactPanels[m.compactPanel] {
+	case panelStats:
+		content = statsContent
+	case panelChanges:
+		content = changesContent
+	case panelTimeline:
+		content = timelineContent
+	case panelDevelopers:
+		if m.showLifetimes {
+			content = m.renderLongestLived(m.columnWidth()-6, m.compactPanelHeight()-3)
+		} else {
+			content = m.renderDeveloperStats()
+		}
+	}
+	panel := m.renderPanelWithHeader(titles[m.compactPanel], content, m.columnWidth()-2, m.compactPanelHeight())
+	return lipgloss.JoinVertical(lipgloss.Left, m.renderTabs(titles), panel)
+}
+
+// compactPanelHeight leaves a line for the tab switcher.
+func (m *Model) compactPanelHeight() int {
+	return m.height - 1
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+type completionFlag struct {
+	name   string
+	usage  string
+	isBool bool
+}
+
+func completionFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		isBool := false
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok {
+			isBool = bf.IsBoolFlag()
+		}
+		flags = append(flags, completionFlag{name: f.Name, usage: f.Usage, isBool: isBool})
+	})
+	return flags
+}
+
+// writeCompletion emits a completion script for the given shell covering every flag in fs.
+func writeCompletion(w io.Writer, shell, program string, fs *flag.FlagSet) error {
+	program = filepath.Base(program)
+	program = strings.TrimSuffix(program, filepath.Ext(program))
+	funcName := "_" + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, program)
+	flags := completionFlags(fs)
+
+	switch shell {
+	case "bash":
+		return writeBashCompletion(w, program, funcName, flags)
+	case "zsh":
+		return writeZshCompletion(w, program, funcName, flags)
+	case "fish":
+		return writeFishCompletion(w, program, flags)
+	default:
+		return fmt.Errorf("unsupported shell: %s. supported shells are: bash, zsh, fish", shell)
+	}
+}
+
+func writeBashCompletion(w io.Writer, program, funcName string, flags []completionFlag) error {
+	var words, valueFlags []string
+	for _, f := range flags {
+		words = append(words, "-"+f.name)
+		if !f.isBool {
+			valueFlags = append(valueFlags, "-"+f.name, "--"+f.name)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, `# bash completion for %[1]s
+%[2]s() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    if [[ $COMP_CWORD -ge 2 && "${COMP_WORDS[1]}" == "completion" ]]; then
+        COMPREPLY=( $(compgen -W "bash zsh fish" -- "$cur") )
+        return
+    fi
+
+    case "$prev" in
+        %[3]s)
+            COMPREPLY=( $(compgen -f -- "$cur") )
+            return
+            ;;
+    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=( $(compgen -W "%[4]s" -- "$cur") )
+        return
+    fi
+
+    if [[ $COMP_CWORD -eq 1 ]]; then
+        COMPREPLY=( $(compgen -W "completion" -- "$cur") $(compgen -d -- "$cur") )
+        return
+    fi
+    COMPREPLY=( $(compgen -d -- "$cur") )
+}
+complete -F %[2]s %[1]s
+`, program, funcName, strings.Join(valueFlags, "|"), strings.Join(words, " "))
+	return err
+}
+
+func writeZshCompletion(w io.Writer, program, funcName string, flags []completionFlag) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n", program)
+	fmt.Fprintf(&b, "%s() {\n", funcName)
+	b.WriteString("    if [[ ${words[2]} == completion ]]; then\n")
+	b.WriteString("        _values 'shell' bash zsh fish\n")
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    _arguments \\\n")
+	for _, f := range flags {
+		usage := zshEscape(f.usage)
+		if f.isBool {
+			fmt.Fprintf(&b, "        '-%s[%s]' \\\n", f.name, usage)
+		} else {
+			fmt.Fprintf(&b, "        '-%s[%s]:value:_files' \\\n", f.name, usage)
+		}
+	}
+	b.WriteString("        '1:repository or command:{_alternative \"commands:command:(completion)\" \"dirs:repository:_files -/\"}'\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "compdef %s %s\n", funcName, program)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func zshEscape(s string) string {
+	r := strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`)
+	return r.Replace(s)
+}
+
+func writeFishCompletion(w io.Writer, program string, flags []completionFlag) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s\n", program)
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a completion -d 'Generate a shell completion script'\n", program)
+	fmt.Fprintf(&b, "complete -c %s -f -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'\n", program)
+	for _, f := range flags {
+		usage := strings.ReplaceAll(f.usage, "'", `\'`)
+		if f.isBool {
+			fmt.Fprintf(&b, "complete -c %s -o %s -d '%s'\n", program, f.name, usage)
+		} else {
+			fmt.Fprintf(&b, "complete -c %s -o %s -r -d '%s'\n", program, f.name, usage)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,190 @@
+package main
+
+import "strings"
+
+const (
+	// dagLookahead is how many commits past the visible window are laid out so
+	// lanes opened by later merges still appear inside the window.
+	dagLookahead = 200
+	// dagMaxLanes caps the rendered graph width; extra lanes are elided.
+	dagMaxLanes = 6
+)
+
+// revListOrder returns the rev-list ordering options for the configured view.
+// The DAG lanes rely on parents coming before their children, which
+// --date-order guarantees while keeping the timeline chronological; otherwise
+// rev-list's default order is kept.
+func (c Config) revListOrder() []string {
+	if c.ShowGraph {
+		return []string{"--date-order"}
+	}
+	return nil
+}
+
+// layoutDAG renders git-log-graph style lanes for commits[start:end], one
+// string per commit. Commits are laid out newest first like `git log --graph`
+// (lanes track the parents still expected), then shown oldest first to match
+// the timeline. Every returned row has the same display width.
+func layoutDAG(commits []*commitInfo, start, end int) []string {
+	if start < 0 {
+		start = 0
+	}
+	if end > len(commits) {
+		end = len(commits)
+	}
+	if start >= end {
+		return nil
+	}
+
+	rows := make([][]rune, end-start)
+	var lanes []string
+	last := min(len(commits), end+dagLookahead)
+	for i := last - 1; i >= start; i-- {
+		c := commits[i]
+		before := append([]string(nil), lanes...)
+
+		col := -1
+		var joins []int
+		for j, h := range lanes {
+			if h != c.Hash {
+				continue
+			}
+			if col < 0 {
+				col = j
+			} else {
+				joins = append(joins, j)
+			}
+		}
+		if col < 0 {
+			col = freeLane(lanes, -1)
+			if col == len(lanes) {
+				lanes = append(lanes, "")
+			}
+		}
+
+		lanes[col] = ""
+		for _, j := range joins {
+			lanes[j] = ""
+		}
+		var opens []int
+		if len(c.ParentHashes) > 0 {
+			lanes[col] = c.ParentHashes[0]
+		}
+		for _, p := range c.ParentHashes[min(1, len(c.ParentHashes)):] {
+			if idx := indexOf(lanes, p); idx >= 0 {
+				opens = append(opens, idx)
+				continue
+			}
+			slot := freeLane(lanes, col)
+			if slot == len(lanes) {
+				lanes = append(lanes, "")
+			}
+			lanes[slot] = p
+			opens = append(opens, slot)
+		}
+
+		if i < end {
+			rows[i-start] = renderDAGRow(before, lanes, col, joins, opens)
+		}
+
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+	}
+
+	width := 0
+	for _, r := range rows {
+		width = max(width, len(r))
+	}
+	out := make([]string, len(rows))
+	for i, r := range rows {
+		out[i] = string(r) + strings.Repeat(" ", width-len(r))
+	}
+	return out
+}
+
+// renderDAGRow draws one commit row. before holds the lanes below the commit
+// (newer history) and after the lanes above it (older history).
+func renderDAGRow(before, after []string, col int, joins, opens []int) []rune {
+	n := max(len(before), len(after))
+	lane := func(ls []string, j int) string {
+		if j < len(ls) {
+			return ls[j]
+		}
+		return ""
+	}
+
+	lo, hi := col, col
+	for _, j := range append(append([]int(nil), joins...), opens...) {
+		lo, hi = min(lo, j), max(hi, j)
+	}
+
+	cells := make([]rune, 0, n*2)
+	for j := 0; j < n; j++ {
+		joined, opened := containsInt(joins, j), containsInt(opens, j)
+		passing := lane(before, j) != "" && lane(before, j) == lane(after, j)
+		cell := ' '
+		switch {
+		case j == col:
+			cell = '●'
+		case joined && opened:
+			cell = '├'
+			if j > col {
+				cell = '┤'
+			}
+		case joined:
+			cell = '╭'
+			if j > col {
+				cell = '╮'
+			}
+		case opened:
+			cell = '╰'
+			if j > col {
+				cell = '╯'
+			}
+		case passing && j > lo && j < hi:
+			cell = '┼'
+		case passing:
+			cell = '│'
+		case j > lo && j < hi:
+			cell = '─'
+		}
+		sep := ' '
+		if j >= lo && j < hi {
+			sep = '─'
+		}
+		cells = append(cells, cell, sep)
+	}
+
+	if len(cells) > dagMaxLanes*2 {
+		cells = append(cells[:dagMaxLanes*2-1], '…')
+	}
+	return cells
+}
+
+func freeLane(lanes []string, exclude int) int {
+	for j, h := range lanes {
+		if h == "" && j != exclude {
+			return j
+		}
+	}
+	return len(lanes)
+}
+
+func indexOf(values []string, v string) int {
+	for i, s := range values {
+		if s == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
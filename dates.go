@@ -0,0 +1,37 @@
+package main
+
+import "time"
+
+const unknownDateLabel = "(unknown date)"
+
+// hasUnknownDate reports whether a commit's author date is missing or too old
+// to be real (zero value or around the Unix epoch). Such commits are kept out of
+// the per-year and per-period statistics.
+func hasUnknownDate(c *commitInfo) bool {
+	return c.Date.IsZero() || c.Date.Year() < 1971
+}
+
+// formatCommitDate formats a commit date, or unknownDateLabel when it is unusable.
+func formatCommitDate(c *commitInfo, layout string) string {
+	if hasUnknownDate(c) {
+		return unknownDateLabel
+	}
+	return c.Date.Format(layout)
+}
+
+// knownDateBounds returns the earliest and latest usable dates among commits.
+func knownDateBounds(commits []*commitInfo) (first, last time.Time, ok bool) {
+	for _, c := range commits {
+		if hasUnknownDate(c) {
+			continue
+		}
+		if !ok || c.Date.Before(first) {
+			first = c.Date
+		}
+		if !ok || c.Date.After(last) {
+			last = c.Date
+		}
+		ok = true
+	}
+	return first, last, ok
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnknownDateStaysOutOfTheYears(t *testing.T) {
+	history := []fixtureCommit{
+		{author: "Alice", date: "2023-05-01T10:00:00Z", message: "Start", write: map[string]string{"a.txt": "a\n"}},
+		{author: "Bob", date: "@0 +0000", message: "Imported without a date", write: map[string]string{"b.txt": "b\n"}},
+		{author: "Alice", date: "2024-02-01T10:00:00Z", message: "Carry on", write: map[string]string{"a.txt": "a\nb\n"}},
+	}
+	cfg := fixtureConfig(t, newFixtureRepo(t, history))
+	cfg.Width, cfg.Height = 120, 50
+	m := InitialModel(cfg)
+	m.showDaySeparators = true
+	for _, c := range collectCommits(cfg) {
+		m.appendCommit(c)
+		m.currentCommitIndex = len(m.commits) - 1
+		m.syncStatYears()
+	}
+
+	epoch := m.commits[1]
+	if !hasUnknownDate(epoch) {
+		t.Fatalf("date %v not treated as unknown", epoch.Date)
+	}
+	if got := formatCommitDate(epoch, time.DateOnly); got != unknownDateLabel {
+		t.Errorf("formatCommitDate = %q, want %q", got, unknownDateLabel)
+	}
+	if want := []int{0, 2024, 2023}; !slices.Equal(m.availableStatYears, want) {
+		t.Errorf("years = %v, want %v", m.availableStatYears, want)
+	}
+	timeline := m.renderTimeline(10)
+	if strings.Contains(timeline, "1970") {
+		t.Errorf("timeline shows the epoch:\n%s", timeline)
+	}
+	if !strings.Contains(timeline, unknownDateLabel) {
+		t.Errorf("timeline has no %q separator:\n%s", unknownDateLabel, timeline)
+	}
+	if stats := m.renderDeveloperStats(); !strings.Contains(stats, unknownDateLabel+": 1 commits") {
+		t.Errorf("developer stats don't count the unknown date:\n%s", stats)
+	}
+}
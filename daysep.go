@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// startsDay reports whether commit i is the first of its day in the loaded
+// history. Commits without a usable date count as one day.
+func (m *Model) startsDay(i int) bool {
+	if i == 0 {
+		return true
+	}
+	return commitDay(m.commits[i]) != commitDay(m.commits[i-1])
+}
+
+func commitDay(c *commitInfo) string {
+	return formatCommitDate(c, time.DateOnly)
+}
+
+// daySeparatedWindow picks the commits shown in a timeline of height rows
+// when day separators take rows too. The top row always gets a separator so
+// the window is dated; below it one appears wherever the day changes. The
+// current commit is kept near the middle, as in the plain timeline.
+func (m *Model) daySeparatedWindow(height int) (start, end int) {
+	start, end = m.currentCommitIndex, m.currentCommitIndex+1
+	rows := 2 // The current commit and the separator above it
+	growUp := func() int {
+		// The old top keeps its separator only where a day starts.
+		if m.startsDay(start) {
+			return 2
+		}
+		return 1
+	}
+	growDown := func() int {
+		if m.startsDay(end) {
+			return 2
+		}
+		return 1
+	}
+	for start > 0 && rows+growUp() <= (height+1)/2 {
+		rows += growUp()
+		start--
+	}
+	for end < len(m.commits) && rows+growDown() <= height {
+		rows += growDown()
+		end++
+	}
+	// Near the end of the history, use the leftover rows above.
+	for start > 0 && rows+growUp() <= height {
+		rows += growUp()
+		start--
+	}
+	return start, end
+}
+
+// renderDaySeparator draws the date row put above the first commit of a day.
+func renderDaySeparator(c *commitInfo, width int) string {
+	label := unknownDateLabel
+	if !hasUnknownDate(c) {
+		label = weekdayName(c.Date.Weekday()) + " " + c.Date.Format(time.DateOnly)
+	}
+	line := "── " + label + " "
+	return graphAxisStyle.Render(line + strings.Repeat("─", max(0, width-len([]rune(line)))))
+}
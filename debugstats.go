@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// debugInterval is how often the -debug-stats overlay refreshes its figures.
+const debugInterval = time.Second
+
+type debugTickMsg time.Time
+
+// debugStats backs the -debug-stats overlay. Renders and ticks are counted
+// over a window and the figures shown are refreshed once per window, so they
+// read as rolling averages instead of flickering per frame.
+type debugStats struct {
+	windowStart time.Time
+	renders     int
+	ticks       int
+	renderTotal time.Duration
+
+	fps       float64
+	tickRate  float64
+	avgRender time.Duration
+	lastFrame time.Duration
+	heapAlloc uint64
+	sys       uint64
+	numGC     uint32
+}
+
+func (m *Model) debugTickCmd() tea.Cmd {
+	if m.debug == nil {
+		return nil
+	}
+	return tea.Tick(debugInterval, func(t time.Time) tea.Msg {
+		return debugTickMsg(t)
+	})
+}
+
+// countTick records a progress tick for the tick rate.
+func (d *debugStats) countTick() {
+	if d != nil {
+		d.ticks++
+	}
+}
+
+// sample closes the current window and reads memory usage.
+func (d *debugStats) sample(now time.Time) {
+	if elapsed := now.Sub(d.windowStart).Seconds(); !d.windowStart.IsZero() && elapsed > 0 {
+		d.fps = float64(d.renders) / elapsed
+		d.tickRate = float64(d.ticks) / elapsed
+		if d.renders > 0 {
+			d.avgRender = d.renderTotal / time.Duration(d.renders)
+		}
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	d.heapAlloc, d.sys, d.numGC = mem.HeapAlloc, mem.Sys, mem.NumGC
+	d.windowStart, d.renders, d.ticks, d.renderTotal = now, 0, 0, 0
+}
+
+// overlay draws the figures in the top right corner of the frame.
+func (d *debugStats) overlay(frame string, commits int) string {
+	mib := func(b uint64) string { return fmt.Sprintf("%.1f MiB", float64(b)/(1<<20)) }
+	lines := []string{
+		fmt.Sprintf("render %s (avg %s)", d.lastFrame.Round(10*time.Microsecond), d.avgRender.Round(10*time.Microsecond)),
+		fmt.Sprintf("fps    %.1f", d.fps),
+		fmt.Sprintf("ticks  %.1f/s", d.tickRate),
+		fmt.Sprintf("commits %d", commits),
+		fmt.Sprintf("heap   %s", mib(d.heapAlloc)),
+		fmt.Sprintf("sys    %s (gc %d)", mib(d.sys), d.numGC),
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(panelBorderColor).
+		Foreground(lipgloss.Color("245")).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+
+	width := max(lipgloss.Width(frame), lipgloss.Width(box))
+	height := max(lipgloss.Height(frame), lipgloss.Height(box))
+	canvas := lipgloss.NewCanvas(width, height)
+	canvas.Compose(lipgloss.NewCompositor(
+		lipgloss.NewLayer(frame),
+		lipgloss.NewLayer(box).X(width-lipgloss.Width(box)).Z(1),
+	))
+	return canvas.Render()
+}
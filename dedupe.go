@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// computePatchIDs maps each non-merge commit to its stable patch-id by piping
+// `git log -p` through `git patch-id`. Commits with equal patch-ids introduce the
+// same change, e.g. cherry-picks.
+func computePatchIDs(repoPath, rev string) (map[string]string, error) {
+	start := time.Now()
+	logCmd := exec.Command("git", "-C", repoPath, "log", "-p", "--no-color", "--no-renames", rev)
+	idCmd := exec.Command("git", "-C", repoPath, "patch-id", "--stable")
+
+	logOut, err := logCmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe for git log: %v", err)
+	}
+	idCmd.Stdin = logOut
+	idOut, err := idCmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe for git patch-id: %v", err)
+	}
+
+	if err := idCmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start git patch-id: %v", err)
+	}
+	if err := logCmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start git log: %v", err)
+	}
+
+	ids := make(map[string]string)
+	scanner := bufio.NewScanner(idOut)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			ids[fields[1]] = fields[0]
+		}
+	}
+
+	if err := logCmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git log failed: %v", err)
+	}
+	if err := idCmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git patch-id failed: %v", err)
+	}
+	slog.Info("computed patch-ids", "commits", len(ids), "elapsed", time.Since(start))
+	return ids, nil
+}
+
+// duplicateTracker remembers the first commit seen for each patch-id.
+type duplicateTracker struct {
+	patchIDs map[string]string // commit -> patch-id
+	firstBy  map[string]string // patch-id -> first commit
+}
+
+func newDuplicateTracker(patchIDs map[string]string) *duplicateTracker {
+	return &duplicateTracker{patchIDs: patchIDs, firstBy: make(map[string]string)}
+}
+
+// mark flags c as a duplicate of an earlier commit with the same patch-id and
+// collapses its stats so the change is only counted once. Commits must be
+// passed in history order.
+func (d *duplicateTracker) mark(c *commitInfo) {
+	if d == nil {
+		return
+	}
+	id, ok := d.patchIDs[c.Hash]
+	if !ok {
+		return
+	}
+	first, seen := d.firstBy[id]
+	if !seen {
+		d.firstBy[id] = c.Hash
+		return
+	}
+	c.DuplicateOf = first
+	c.Files, c.Additions, c.Deletions, c.Churn = 0, 0, 0, 0
+	c.DirChurn = nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var (
+	demoAuthors = []string{
+		"Ada Lovelace", "Grace Hopper", "Linus Torvalds", "Margaret Hamilton",
+		"Ken Thompson", "Barbara Liskov", "Dennis Ritchie", "Frances Allen",
+	}
+	demoDirs    = []string{"cmd", "internal/render", "internal/stats", "pkg/git", "docs", ".github/workflows"}
+	demoVerbs   = []string{"Add", "Fix", "Refactor", "Update", "Remove", "Improve", "Document", "Test"}
+	demoSubject = []string{
+		"parser error handling", "config loading", "render loop", "cache invalidation",
+		"CLI flags", "timeline layout", "release workflow", "dependency versions",
+		"stats aggregation", "diff viewer", "logging", "README examples",
+	}
+)
+
+// generateDemoCommits feeds a deterministic stream of synthetic commits into
+// processedCommitsChan so the UI can be exercised without a repository.
+func (m *Model) generateDemoCommits() {
+	size := m.config.DemoSize
+	if size <= 0 {
+		size = 500
+	}
+	if m.config.CommitLimit > 0 && m.config.CommitLimit < size {
+		size = m.config.CommitLimit
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	date := time.Date(2020, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	prevHash := ""
+	for i := 0; i < size; i++ {
+		// Mostly working hours on weekdays, with the occasional late night,
+		// weekend or long gap.
+		gap := rng.Intn(3)
+		if rng.Intn(30) == 0 {
+			gap += rng.Intn(14)
+		}
+		next := date.AddDate(0, 0, gap)
+		hour := 8 + rng.Intn(11)
+		if rng.Intn(10) == 0 {
+			hour = 20 + rng.Intn(4)
+		}
+		next = time.Date(next.Year(), next.Month(), next.Day(), hour, rng.Intn(60), 0, 0, time.UTC)
+		if !next.After(date) {
+			next = next.AddDate(0, 0, 1)
+		}
+		for rng.Intn(15) != 0 && (next.Weekday() == time.Saturday || next.Weekday() == time.Sunday) {
+			next = next.AddDate(0, 0, 1)
+		}
+		date = next
+
+		// Pareto-like author weighting so a few people dominate.
+		author := demoAuthors[int(math.Min(float64(len(demoAuthors)-1), rng.ExpFloat64()*2))]
+		// Most changes land through a single maintainer, like a squash-merge flow.
+		committer := author
+		if i%4 != 0 {
+			committer = demoAuthors[0]
+		}
+
+		additions := int(math.Exp(rng.NormFloat64()*1.4 + 3))
+		deletions := int(float64(additions) * rng.Float64() * 0.9)
+		if i == 0 {
+			additions, deletions = 1200+rng.Intn(800), 0
+		}
+		files := 1 + int(math.Sqrt(float64(additions+deletions))/3)
+
+		fileStats := make([]fileStat, files)
+		for f := range fileStats {
+			fileStats[f].Name = fmt.Sprintf("%s/file%d.go", demoDirs[rng.Intn(len(demoDirs))], rng.Intn(20))
+			fileStats[f].Additions = additions / files
+			fileStats[f].Deletions = deletions / files
+		}
+		fileStats[0].Additions += additions % files
+		fileStats[0].Deletions += deletions % files
+
+		message := fmt.Sprintf("%s %s", demoVerbs[rng.Intn(len(demoVerbs))], demoSubject[rng.Intn(len(demoSubject))])
+		if i == 0 {
+			message = "Initial commit"
+		}
+
+		hash := fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("demo-%d", i))))
+		var parents []string
+		if prevHash != "" {
+			parents = []string{prevHash}
+		}
+		prevHash = hash
+
+		m.processedCommitsChan <- &commitInfo{
+			Hash:      hash,
+			Message:   message,
+			Author:    author,
+			Committer: committer,
+			Date:      date,
+			Files:     files,
+			Additions: additions,
+			Deletions: deletions,
+			Churn:     computeChurn(m.config.ChurnMode, additions, deletions),
+
+			ParentHashes:    parents,
+			ParentCount:     len(parents),
+			DirChurn:        dirChurn(fileStats),
+			FileStats:       fileStats,
+			FileStatsLoaded: true,
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// developerStats is what the developer stats panel shows for a set of
+// commits. aggregateDeveloperStats fills it for both the TUI and -export-json.
+type developerStats struct {
+	authors      map[string]*authorStat // By authorKey
+	months       map[time.Month]int
+	weekdays     map[time.Weekday]int
+	hours        map[int]int     // Local time
+	daily        map[busyDay]int // Commits per author and day, for busyDays
+	unknownDates int             // Counted per author but left out of the charts
+}
+
+// authorGrouping decides whose name a commit is counted under.
+type authorGrouping struct {
+	byCommitter bool // Committer instead of author, where recorded
+	normalize   bool // See authorKeyFor
+}
+
+func (m *Model) authorGrouping() authorGrouping {
+	return authorGrouping{byCommitter: m.byCommitter, normalize: m.config.NormalizeAuthors}
+}
+
+// authorKeyFor groups author names for the contributor stats. Unless exact
+// names are wanted, names differing only in case or spacing count as one
+// person; the first spelling seen is the one displayed.
+func authorKeyFor(name string, normalize bool) string {
+	if !normalize {
+		return name
+	}
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// nameOf is the name c is counted under.
+func (g authorGrouping) nameOf(c *commitInfo) string {
+	if g.byCommitter && c.Committer != "" {
+		return c.Committer
+	}
+	return c.Author
+}
+
+// keyOf is the authorKey c is counted under.
+func (g authorGrouping) keyOf(c *commitInfo) string {
+	return authorKeyFor(g.nameOf(c), g.normalize)
+}
+
+// tally adds c to the stats of whoever it is counted under and returns them.
+func (g authorGrouping) tally(authors map[string]*authorStat, c *commitInfo) *authorStat {
+	name := g.nameOf(c)
+	key := authorKeyFor(name, g.normalize)
+	a := authors[key]
+	if a == nil {
+		a = &authorStat{key: key, name: strings.TrimSpace(name)}
+		authors[key] = a
+	}
+	a.churn += c.Churn
+	a.commits++
+	a.additions += c.Additions
+	a.deletions += c.Deletions
+	if !hasUnknownDate(c) && c.Date.After(a.last) {
+		a.last = c.Date
+	}
+	return a
+}
+
+// commitsInYear returns the commits dated in year, or all of them for 0.
+func commitsInYear(commits []*commitInfo, year int) []*commitInfo {
+	if year == 0 {
+		return commits
+	}
+	var inYear []*commitInfo
+	for _, c := range commits {
+		if !hasUnknownDate(c) && c.Date.Year() == year {
+			inYear = append(inYear, c)
+		}
+	}
+	return inYear
+}
+
+func newDeveloperStats() developerStats {
+	return developerStats{
+		authors:  make(map[string]*authorStat),
+		months:   make(map[time.Month]int),
+		weekdays: make(map[time.Weekday]int),
+		hours:    make(map[int]int),
+		daily:    make(map[busyDay]int),
+	}
+}
+
+// add counts c under whoever g attributes it to.
+func (s *developerStats) add(c *commitInfo, g authorGrouping) {
+	a := g.tally(s.authors, c)
+	if hasUnknownDate(c) {
+		s.unknownDates++
+		return
+	}
+	s.daily[busyDay{author: a.name, day: c.Date.Format("2006-01-02")}]++
+	s.weekdays[c.Date.Weekday()]++
+	s.months[c.Date.Month()]++
+	s.hours[c.Date.Local().Hour()]++
+}
+
+func aggregateDeveloperStats(commits []*commitInfo, g authorGrouping) developerStats {
+	s := newDeveloperStats()
+	for _, c := range commits {
+		s.add(c, g)
+	}
+	return s
+}
+
+// runningDevStats is aggregateDeveloperStats of the commits up to the current
+// one in the selected year, kept in step with playback: moving forward only
+// counts the commits passed since the last frame, and the top contributors are
+// only ranked again once the counts or the sort change. Anything else, such as
+// a rewind or another year, starts over.
+type runningDevStats struct {
+	stats    developerStats
+	next     int    // commits[:next] have been visited
+	lastHash string // commits[next-1].Hash, to notice replaced commits
+	year     int
+	grouping authorGrouping
+	top      []authorStat // nil once stats change
+	topMode  int
+}
+
+// developerStats brings m.devStats up to the current commit and returns it.
+func (m *Model) developerStats() *developerStats {
+	r := &m.devStats
+	end := min(m.currentCommitIndex+1, len(m.commits))
+	g := m.authorGrouping()
+	if r.stats.authors == nil || r.year != m.displayedStatsYear || r.grouping != g || r.next > end ||
+		(r.next > 0 && m.commits[r.next-1].Hash != r.lastHash) {
+		*r = runningDevStats{stats: newDeveloperStats(), year: m.displayedStatsYear, grouping: g}
+	}
+	for ; r.next < end; r.next++ {
+		c := m.commits[r.next]
+		if r.year == 0 || (!hasUnknownDate(c) && c.Date.Year() == r.year) {
+			r.stats.add(c, g)
+			r.top = nil
+		}
+		r.lastHash = c.Hash
+	}
+	return &r.stats
+}
+
+// topContributors returns the five highest-ranked authors of the current
+// stats under the chosen sort.
+func (m *Model) topContributors() []authorStat {
+	stats := m.developerStats()
+	r := &m.devStats
+	if r.top == nil || r.topMode != m.contributorSort {
+		r.top, r.topMode = topContributors(stats.authors, m.contributorSort, 5), m.contributorSort
+	}
+	return r.top
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunningDeveloperStatsMatchAggregation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := InitialModel(fixtureConfig(t, t.TempDir()))
+	for i := range 300 {
+		date := time.Date(2022+rng.Intn(3), time.Month(1+rng.Intn(12)), 1+rng.Intn(28), rng.Intn(24), 0, 0, 0, time.UTC)
+		if i%50 == 0 {
+			date = time.Time{}
+		}
+		m.appendCommit(&commitInfo{
+			Hash:      fmt.Sprintf("%040d", i),
+			Author:    []string{"Alice", "alice", "Bob", "Carol", "Dan"}[rng.Intn(5)],
+			Committer: []string{"Alice", "Eve"}[rng.Intn(2)],
+			Date:      date,
+			Additions: rng.Intn(40),
+			Deletions: rng.Intn(40),
+			Churn:     rng.Intn(80),
+		})
+	}
+
+	// Forward steps, rewinds, year and grouping changes, in playback order.
+	steps := []func(){
+		func() { m.currentCommitIndex = 10 },
+		func() { m.currentCommitIndex = 11 },
+		func() { m.currentCommitIndex = 150 },
+		func() { m.currentCommitIndex = 40 },
+		func() { m.displayedStatsYear = 2023 },
+		func() { m.currentCommitIndex = 299 },
+		func() { m.byCommitter = true },
+		func() { m.displayedStatsYear = 0 },
+		func() { m.contributorSort = sortByRecent },
+		func() { m.currentCommitIndex = 0 },
+	}
+	for i, step := range steps {
+		step()
+		g := m.authorGrouping()
+		want := aggregateDeveloperStats(m.statsCommits(), g)
+		if got := m.developerStats(); !reflect.DeepEqual(*got, want) {
+			t.Fatalf("step %d: running stats differ from a full aggregation", i)
+		}
+		if got, want := m.topContributors(), topContributors(want.authors, m.contributorSort, 5); !reflect.DeepEqual(got, want) {
+			t.Fatalf("step %d: top contributors = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestMixedCaseAuthors(t *testing.T) {
+	var commits []*commitInfo
+	for i, name := range []string{"Alice Smith", "alice  smith", "ALICE SMITH", "Bob"} {
+		commits = append(commits, &commitInfo{Hash: fmt.Sprintf("%040d", i), Author: name, Churn: 10})
+	}
+
+	if fixtureConfig(t, "").NormalizeAuthors {
+		t.Error("author names are normalized by default, want them kept exact")
+	}
+	exact := aggregateDeveloperStats(commits, authorGrouping{})
+	if len(exact.authors) != 4 {
+		t.Errorf("exact names: %d authors, want 4", len(exact.authors))
+	}
+
+	grouped := aggregateDeveloperStats(commits, authorGrouping{normalize: true})
+	if len(grouped.authors) != 2 {
+		t.Fatalf("normalized names: %d authors, want 2", len(grouped.authors))
+	}
+	alice := grouped.authors[authorKeyFor("Alice Smith", true)]
+	if alice == nil || alice.name != "Alice Smith" || alice.commits != 3 || alice.churn != 30 {
+		t.Errorf("normalized Alice = %+v, want 3 commits and 30 churn as Alice Smith", alice)
+	}
+}
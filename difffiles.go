@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diffFileStarts returns the indices of the "diff --git" header lines, one
+// per file in the diff.
+func diffFileStarts(lines []string) []int {
+	var starts []int
+	for i, line := range lines {
+		if strings.HasPrefix(line, "diff --git ") {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// diffFileAt returns the index into diffFileStarts of the file shown at the
+// top of the diff view, or -1 above the first file.
+func (m *Model) diffFileAt() int {
+	return sort.SearchInts(m.diffFileStarts, m.diffScroll+1) - 1
+}
+
+// jumpToFile scrolls the diff view to the next file's header, or to the
+// previous one's for a negative dir.
+func (m *Model) jumpToFile(dir int) {
+	if len(m.diffFileStarts) == 0 {
+		m.diffNotice = "no files"
+		return
+	}
+	i := m.diffFileAt()
+	if dir < 0 && i >= 0 && m.diffScroll > m.diffFileStarts[i] {
+		// Partway into a file, going back starts with its own header.
+		i++
+	}
+	i += dir
+	switch {
+	case i >= len(m.diffFileStarts):
+		m.diffNotice = "last file"
+	case i < 0:
+		m.diffNotice = "first file"
+	default:
+		m.diffScroll = m.diffFileStarts[i]
+	}
+}
+
+// diffFileLabel is the "file 3/12" part of the diff status line.
+func (m *Model) diffFileLabel() string {
+	n := len(m.diffFileStarts)
+	if n == 0 {
+		return ""
+	}
+	if i := m.diffFileAt(); i >= 0 {
+		return fmt.Sprintf("file %d/%d", i+1, n)
+	}
+	if n == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", n)
+}
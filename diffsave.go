@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// saveDiff writes the diff on screen to <short hash>.diff (or <a>..<b>.diff for
+// a range) in the working directory and reports the outcome in the status line.
+func (m *Model) saveDiff() {
+	if len(m.commits) == 0 {
+		return
+	}
+	name := m.commits[m.currentCommitIndex].Hash[:7] + ".diff"
+	if m.diffIsRange {
+		name = m.markA[:7] + ".." + m.markB[:7] + ".diff"
+	}
+	if err := os.WriteFile(name, []byte(m.currentDiff), 0o644); err != nil {
+		m.diffNotice = fmt.Sprintf("save failed: %v", err)
+		return
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		name = abs
+	}
+	m.diffNotice = "saved " + name
+}
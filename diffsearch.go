@@ -0,0 +1,76 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// editDiffSearch handles a key while the / prompt is open: enter searches,
+// esc gives up, and anything else edits the query.
+func (m *Model) editDiffSearch(msg tea.KeyPressMsg) {
+	switch msg.String() {
+	case "enter":
+		m.diffSearchPrompt = false
+		if m.diffSearchInput == "" {
+			m.diffSearch = nil
+			return
+		}
+		m.diffSearch = regexp.MustCompile("(?i)" + regexp.QuoteMeta(m.diffSearchInput))
+		m.jumpToMatch(1, m.diffScroll-1)
+	case "esc":
+		m.diffSearchPrompt = false
+	case "backspace":
+		if r := []rune(m.diffSearchInput); len(r) > 0 {
+			m.diffSearchInput = string(r[:len(r)-1])
+		}
+	default:
+		m.diffSearchInput += msg.Text
+	}
+}
+
+// jumpToMatch scrolls to the next line after from (or before it, for a
+// negative dir) matching the search, wrapping around the ends of the diff.
+func (m *Model) jumpToMatch(dir, from int) {
+	if m.diffSearch == nil {
+		m.diffNotice = "/: search"
+		return
+	}
+	n := len(m.currentDiffLines)
+	for step := 1; step <= n; step++ {
+		i := ((from+dir*step)%n + n) % n
+		if m.diffSearch.MatchString(m.currentDiffLines[i]) {
+			if (dir > 0 && i <= from) || (dir < 0 && i >= from) {
+				m.diffNotice = "search wrapped"
+			}
+			m.diffScroll = i
+			return
+		}
+	}
+	m.diffNotice = "no match: " + m.diffSearchInput
+}
+
+// highlightMatches renders line in style with the search matches picked out.
+func (m *Model) highlightMatches(line string, style lipgloss.Style) string {
+	if m.diffSearch == nil {
+		return style.Render(line)
+	}
+	matches := m.diffSearch.FindAllStringIndex(line, -1)
+	if matches == nil {
+		return style.Render(line)
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		b.WriteString(style.Render(line[last:match[0]]))
+		b.WriteString(searchMatchStyle.Render(line[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(style.Render(line[last:]))
+	return b.String()
+}
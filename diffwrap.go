@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// wrapIndent starts the continuation rows of a wrapped diff line.
+const wrapIndent = "  "
+
+// diffWrapTop is the first row shown when wrapping: row is how many
+// continuation rows of line are scrolled past. It only applies while line is
+// still diffScroll, so anything that moves diffScroll directly starts at the
+// top of its line.
+type diffWrapTop struct {
+	line, row int
+}
+
+// wrapWidth is the width diff lines wrap at, leaving room for the indent and
+// any line number gutter.
+func (m *Model) wrapWidth() int {
+	return max(m.width+viewMargin-len(wrapIndent)-lipgloss.Width(m.lineNumberGutter(-1)), minWrapWidth)
+}
+
+// wrappedDiffLine renders diff line i as the rows it wraps to, continuation
+// rows indented and in the line's own colors.
+func (m *Model) wrappedDiffLine(i int, lang *syntaxLanguage, pairs map[int]int) []string {
+	rendered := m.renderDiffLine(i, lang, -1, pairs)
+	width := m.wrapWidth()
+	gutter := m.lineNumberGutter(i)
+	if lipgloss.Width(rendered) <= width {
+		return []string{gutter + rendered}
+	}
+	rows := strings.Split(lipgloss.NewStyle().Width(width).Render(rendered), "\n")
+	rows[0] = gutter + rows[0]
+	for r := 1; r < len(rows); r++ {
+		rows[r] = m.lineNumberGutter(-1) + wrapIndent + rows[r]
+	}
+	return rows
+}
+
+// diffWrapRow is how far into the line at diffScroll the view starts.
+func (m *Model) diffWrapRow() int {
+	if m.diffWrapTop.line != m.diffScroll {
+		return 0
+	}
+	return m.diffWrapTop.row
+}
+
+// scrollWrapped moves the wrapped diff view by delta rows.
+func (m *Model) scrollWrapped(delta int) {
+	lines := len(m.currentDiffLines)
+	line, row := min(m.diffScroll, max(lines-1, 0)), m.diffWrapRow()
+	rowsOf := func(i int) int { return len(m.wrappedDiffLine(i, nil, nil)) }
+	for ; delta > 0; delta-- {
+		if row+1 < rowsOf(line) {
+			row++
+		} else if line+1 < lines {
+			line, row = line+1, 0
+		} else {
+			break
+		}
+	}
+	for ; delta < 0; delta++ {
+		if row > 0 {
+			row--
+		} else if line > 0 {
+			line--
+			row = rowsOf(line) - 1
+		} else {
+			break
+		}
+	}
+	m.diffScroll = line
+	m.diffWrapTop = diffWrapTop{line: line, row: row}
+}
+
+// renderWrappedDiff fills height rows with the diff from the top row,
+// wrapping long lines instead of leaving them to the terminal.
+func (m *Model) renderWrappedDiff(height int) string {
+	var lang *syntaxLanguage
+	if m.config.SyntaxHighlight {
+		lang = m.diffLanguageAt(m.diffScroll)
+	}
+	pairs := m.wordDiffPairs()
+	skip := m.diffWrapRow()
+	var b strings.Builder
+	for i := max(m.diffScroll, 0); i < len(m.currentDiffLines) && height > 0; i++ {
+		line := m.currentDiffLines[i]
+		if m.config.SyntaxHighlight {
+			if l, ok := diffFileLanguage(line); ok {
+				lang = l
+			}
+		}
+		rows := m.wrappedDiffLine(i, lang, pairs)
+		if eol, ok := m.diffLineEndings[i]; ok {
+			rows[len(rows)-1] += graphAxisStyle.Render("  " + eol.label())
+		}
+		for _, row := range rows[min(skip, len(rows)-1):] {
+			if height == 0 {
+				break
+			}
+			b.WriteString(row + "\n")
+			height--
+		}
+		skip = 0
+	}
+	return b.String()
+}
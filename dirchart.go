@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// dirChartMaxDirs caps the directories given their own colour; the rest are
+// stacked together as "other".
+const dirChartMaxDirs = 6
+
+const rootDirLabel = "(root)"
+
+var dirChartColors = []string{"39", "208", "141", "78", "220", "204", "244"}
+
+// topLevelDir returns the first path component, or rootDirLabel for files at the
+// repository root.
+func topLevelDir(path string) string {
+	if i := strings.IndexByte(path, '/'); i > 0 {
+		return path[:i]
+	}
+	return rootDirLabel
+}
+
+// dirChurn sums additions and deletions per top-level directory.
+func dirChurn(stats []fileStat) map[string]int {
+	if len(stats) == 0 {
+		return nil
+	}
+	churn := make(map[string]int)
+	for _, s := range stats {
+		churn[topLevelDir(s.Name)] += s.Additions + s.Deletions
+	}
+	return churn
+}
+
+// dirBucket is one row of the directory chart.
+type dirBucket struct {
+	label string
+	churn map[string]int
+	total int
+}
+
+// bucketDirChurn groups directory churn by month, falling back to quarters and
+// then years so the whole history fits in rows.
+func bucketDirChurn(commits []*commitInfo, rows int) []dirBucket {
+	keys := []func(c *commitInfo) string{
+		func(c *commitInfo) string { return formatCommitDate(c, "2006-01") },
+		func(c *commitInfo) string {
+			if hasUnknownDate(c) {
+				return unknownDateLabel
+			}
+			return fmt.Sprintf("%d-Q%d", c.Date.Year(), (int(c.Date.Month())+2)/3)
+		},
+		func(c *commitInfo) string { return formatCommitDate(c, "2006") },
+	}
+
+	var buckets []dirBucket
+	for _, key := range keys {
+		buckets = buckets[:0]
+		for _, c := range commits {
+			label := key(c)
+			if len(buckets) == 0 || buckets[len(buckets)-1].label != label {
+				buckets = append(buckets, dirBucket{label: label, churn: make(map[string]int)})
+			}
+			b := &buckets[len(buckets)-1]
+			for dir, n := range c.DirChurn {
+				b.churn[dir] += n
+				b.total += n
+			}
+		}
+		if len(buckets) <= rows {
+			break
+		}
+	}
+	return buckets
+}
+
+// renderDirChart draws churn per top-level directory over time as stacked bars,
+// one bar per time bucket, newest at the bottom.
+func (m *Model) renderDirChart(width, height int) string {
+	if len(m.commits) == 0 || height < 3 {
+		return "Insufficient data"
+	}
+	commits := m.commits[:m.currentCommitIndex+1]
+
+	totals := make(map[string]int)
+	for _, c := range commits {
+		for dir, n := range c.DirChurn {
+			totals[dir] += n
+		}
+	}
+	if len(totals) == 0 {
+		return graphAxisStyle.Render("No directory data")
+	}
+	dirs := make([]string, 0, len(totals))
+	for dir := range totals {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if totals[dirs[i]] != totals[dirs[j]] {
+			return totals[dirs[i]] > totals[dirs[j]]
+		}
+		return dirs[i] < dirs[j]
+	})
+	other := ""
+	if len(dirs) > dirChartMaxDirs {
+		dirs = dirs[:dirChartMaxDirs]
+		other = "other"
+	}
+	series := dirs
+	if other != "" {
+		series = append(append([]string(nil), dirs...), other)
+	}
+	colorOf := make(map[string]lipgloss.Style, len(series))
+	for i, dir := range series {
+		colorOf[dir] = lipgloss.NewStyle().Foreground(lipgloss.Color(dirChartColors[i%len(dirChartColors)]))
+	}
+
+	rows := height - 1 // legend
+	buckets := bucketDirChurn(commits, rows)
+	if len(buckets) > rows {
+		buckets = buckets[len(buckets)-rows:]
+	}
+	maxTotal := 1
+	for _, b := range buckets {
+		maxTotal = max(maxTotal, b.total)
+	}
+
+	const labelWidth = 8
+	barWidth := max(10, width-labelWidth-9)
+
+	var sb strings.Builder
+	legendWidth := 0
+	for _, dir := range series {
+		entry := barChar + " " + truncatePath(dir, 16) + "  "
+		if legendWidth+lipgloss.Width(entry) > width {
+			break
+		}
+		legendWidth += lipgloss.Width(entry)
+		sb.WriteString(colorOf[dir].Render(barChar) + " " + truncatePath(dir, 16) + "  ")
+	}
+	sb.WriteString("\n")
+
+	for _, b := range buckets {
+		length := b.total * barWidth / maxTotal
+		var bar strings.Builder
+		drawn, acc := 0, 0
+		for _, dir := range series {
+			n := b.churn[dir]
+			if dir == other {
+				n = b.total
+				for _, d := range dirs {
+					n -= b.churn[d]
+				}
+			}
+			acc += n
+			end := 0
+			if b.total > 0 {
+				end = acc * length / b.total
+			}
+			if end > drawn {
+				bar.WriteString(colorOf[dir].Render(strings.Repeat(barChar, end-drawn)))
+				drawn = end
+			}
+		}
+		label := b.label
+		if label == unknownDateLabel {
+			label = "unknown"
+		}
+		sb.WriteString(fmt.Sprintf("%-*s|%s %s\n", labelWidth, label, bar.String(), formatStat(b.total)))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// modelSnapshot is the part of Model worth attaching to a bug report. Commits,
+// diffs and caches are left out; counts are enough to reproduce most issues.
+type modelSnapshot struct {
+	Time               time.Time `json:"time"`
+	Config             Config    `json:"config"`
+	Width              int       `json:"width"`
+	Height             int       `json:"height"`
+	Commits            int       `json:"commits"`
+	CurrentCommitIndex int       `json:"current_commit_index"`
+	CurrentHash        string    `json:"current_hash,omitempty"`
+	LoadingComplete    bool      `json:"loading_complete"`
+	SkippedCommits     int       `json:"skipped_commits"`
+	AutoProgress       bool      `json:"auto_progress"`
+	ProgressInterval   string    `json:"progress_interval"`
+	TourPaused         bool      `json:"tour_paused"`
+	ShowGraph          bool      `json:"show_graph"`
+	ShowDirChart       bool      `json:"show_dir_chart"`
+	DeletionsOnTop     bool      `json:"deletions_on_top"`
+	GraphMode          string    `json:"graph_mode"`
+	ContributorSort    int       `json:"contributor_sort"`
+	ShowLifetimes      bool      `json:"show_lifetimes"`
+	StatsPerCommit     bool      `json:"stats_per_commit"`
+	ByCommitter        bool      `json:"by_committer"`
+	DisplayedStatsYear int       `json:"displayed_stats_year"`
+	AvailableStatYears []int     `json:"available_stat_years"`
+	MarkA              string    `json:"mark_a,omitempty"`
+	MarkB              string    `json:"mark_b,omitempty"`
+	DiffState          int       `json:"diff_state"`
+	DiffFor            string    `json:"diff_for,omitempty"`
+	DiffScroll         int       `json:"diff_scroll"`
+	DiffContext        int       `json:"diff_context"`
+	DiffLines          int       `json:"diff_lines"`
+	DiffCacheEntries   int       `json:"diff_cache_entries"`
+	IdleActive         bool      `json:"idle_active"`
+	TicksStopped       bool      `json:"ticks_stopped"`
+	ReportEngine       string    `json:"report_engine,omitempty"`
+	ReportProcessed    int       `json:"report_processed"`
+	ReportTotal        int       `json:"report_total"`
+}
+
+func (m *Model) snapshot() modelSnapshot {
+	s := modelSnapshot{
+		Time:               m.nowFunc(),
+		Config:             m.config,
+		Width:              m.width,
+		Height:             m.height,
+		Commits:            len(m.commits),
+		CurrentCommitIndex: m.currentCommitIndex,
+		LoadingComplete:    m.loadingComplete,
+		SkippedCommits:     len(m.skippedCommits),
+		AutoProgress:       m.autoProgress,
+		ProgressInterval:   m.progressInterval.String(),
+		TourPaused:         m.tourPaused,
+		ShowGraph:          m.showGraph,
+		ShowDirChart:       m.showDirChart,
+		DeletionsOnTop:     m.deletionsOnTop,
+		GraphMode:          m.graphMode,
+		ContributorSort:    m.contributorSort,
+		ShowLifetimes:      m.showLifetimes,
+		StatsPerCommit:     m.statsPerCommit,
+		ByCommitter:        m.byCommitter,
+		DisplayedStatsYear: m.displayedStatsYear,
+		AvailableStatYears: m.availableStatYears,
+		MarkA:              m.markA,
+		MarkB:              m.markB,
+		DiffState:          int(m.diffState),
+		DiffFor:            m.currentDiffFor,
+		DiffScroll:         m.diffScroll,
+		DiffContext:        m.diffContext,
+		DiffLines:          len(m.currentDiffLines),
+		DiffCacheEntries:   len(m.diffCache),
+		IdleActive:         m.idleActive,
+		TicksStopped:       m.ticksStopped,
+		ReportEngine:       m.reportEngine,
+		ReportProcessed:    m.reportProcessed,
+		ReportTotal:        m.reportTotal,
+	}
+	if m.currentCommitIndex < len(m.commits) {
+		s.CurrentHash = m.commits[m.currentCommitIndex].Hash
+	}
+	return s
+}
+
+// dumpState writes a JSON snapshot of the model to visagit-dump-<time>.json in
+// the working directory for attaching to bug reports.
+func (m *Model) dumpState() {
+	data, err := json.MarshalIndent(m.snapshot(), "", "  ")
+	if err != nil {
+		m.notice = fmt.Sprintf("dump failed: %v", err)
+		return
+	}
+	name := "visagit-dump-" + m.nowFunc().Format("20060102-150405") + ".json"
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		m.notice = fmt.Sprintf("dump failed: %v", err)
+		return
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		name = abs
+	}
+	m.notice = "saved " + name
+}
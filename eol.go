@@ -0,0 +1,70 @@
+package main
+
+import "strings"
+
+const utf8BOM = "\ufeff"
+
+// eolInfo records what normalizeDiffLine hides for one file of a diff.
+type eolInfo struct {
+	crlf bool
+	bom  bool
+}
+
+// scanLineEndings finds the files in a diff whose content has CRLF line
+// endings or a byte order mark, keyed by the index of their "diff --git" line.
+func scanLineEndings(lines []string) map[int]eolInfo {
+	var found map[int]eolInfo
+	header := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "diff --git ") {
+			header = i
+			continue
+		}
+		if header < 0 || line == "" {
+			continue
+		}
+		switch line[0] {
+		case '+', '-', ' ':
+		default:
+			continue
+		}
+		if strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- ") {
+			continue
+		}
+		crlf := strings.HasSuffix(line, "\r")
+		bom := strings.HasPrefix(line[1:], utf8BOM)
+		if !crlf && !bom {
+			continue
+		}
+		if found == nil {
+			found = make(map[int]eolInfo)
+		}
+		info := found[header]
+		info.crlf = info.crlf || crlf
+		info.bom = info.bom || bom
+		found[header] = info
+	}
+	return found
+}
+
+// normalizeDiffLine drops a trailing CR and a leading byte order mark from a
+// diff line for display.
+func normalizeDiffLine(line string) string {
+	line = strings.TrimSuffix(line, "\r")
+	if len(line) > 0 && strings.HasPrefix(line[1:], utf8BOM) {
+		line = line[:1] + line[1+len(utf8BOM):]
+	}
+	return line
+}
+
+// label is the marker shown after a file's diff header.
+func (e eolInfo) label() string {
+	var parts []string
+	if e.crlf {
+		parts = append(parts, "CRLF")
+	}
+	if e.bom {
+		parts = append(parts, "BOM")
+	}
+	return strings.Join(parts, " ")
+}
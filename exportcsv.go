@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// exportCommitsCSV loads the whole history without the TUI and writes one
+// row per commit to path, with the running totals the stats panel shows.
+func exportCommitsCSV(cfg Config, path string) error {
+	commits := collectCommits(cfg)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV export: %v", err)
+	}
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"hash", "author", "date", "files", "additions", "deletions", "churn", "cumulative_files", "cumulative_additions", "cumulative_deletions"})
+	for _, c := range commits {
+		date := ""
+		if !hasUnknownDate(c) {
+			date = c.Date.Format(time.RFC3339)
+		}
+		w.Write([]string{
+			c.Hash,
+			c.Author,
+			date,
+			strconv.Itoa(c.Files),
+			strconv.Itoa(c.Additions),
+			strconv.Itoa(c.Deletions),
+			strconv.Itoa(c.Churn),
+			strconv.Itoa(c.CumulativeFiles),
+			strconv.Itoa(c.CumulativeAdditions),
+			strconv.Itoa(c.CumulativeDeletions),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write CSV export: %v", err)
+	}
+	return f.Close()
+}
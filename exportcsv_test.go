@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportCommitsCSVCountsRootCommit(t *testing.T) {
+	cfg := fixtureConfig(t, newFixtureRepo(t, textHistory))
+	path := filepath.Join(t.TempDir(), "commits.csv")
+	if err := exportCommitsCSV(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(textHistory)+1 {
+		t.Fatalf("got %d rows, want a header and %d commits", len(rows), len(textHistory))
+	}
+	// Oldest first: files, additions, deletions, churn of the root commit.
+	root := rows[1][3:7]
+	want := []string{"2", "8", "0", "8"}
+	for i := range want {
+		if root[i] != want[i] {
+			t.Errorf("root commit %s = %s, want %s", rows[0][3+i], root[i], want[i])
+		}
+	}
+	if last := rows[len(rows)-1]; last[8] != "11" || last[9] != "4" {
+		t.Errorf("cumulative additions/deletions = %s/%s, want 11/4", last[8], last[9])
+	}
+}
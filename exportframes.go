@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Frame size for -export-frames when -width/-height are unset, as there is no
+// terminal to follow.
+const (
+	defaultFrameWidth  = 160
+	defaultFrameHeight = 50
+)
+
+// exportFrames plays the history without the TUI and writes the dashboard as
+// it looks after each commit to dir as frame-000001.txt and on, colors kept.
+// Playing them back is a matter of `clear; cat` with a sleep in between.
+func exportFrames(cfg Config, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create frame directory: %v", err)
+	}
+
+	m := InitialModel(cfg)
+	go m.fetcher()
+	m.resize(defaultFrameWidth, defaultFrameHeight)
+
+	n := 0
+	for c := range m.processedCommitsChan {
+		m.appendCommit(c)
+		m.currentCommitIndex = len(m.commits) - 1
+		n++
+		if err := m.writeFrame(dir, n); err != nil {
+			return err
+		}
+	}
+	if n == 0 {
+		return fmt.Errorf("no commits to export")
+	}
+	// Redraw the last frame without the loading indicator.
+	m.loadingComplete = true
+	return m.writeFrame(dir, n)
+}
+
+func (m *Model) writeFrame(dir string, n int) error {
+	path := filepath.Join(dir, fmt.Sprintf("frame-%06d.txt", n))
+	if err := os.WriteFile(path, []byte(m.View().Content+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write frame: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// exportedStats is the -export-json document: the developer stats panel's
+// data for all time and for each year, newest first, as the year keys cycle
+// through them in the TUI. Names are in English whatever -lang says. The
+// commits themselves are included so -from-json can reopen the export.
+type exportedStats struct {
+	Repo        string           `json:"repo"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	AllTime     exportedPeriod   `json:"all_time"`
+	Years       []exportedYear   `json:"years"`
+	Commits     []exportedCommit `json:"commits"`
+}
+
+type exportedYear struct {
+	Year int `json:"year"`
+	exportedPeriod
+}
+
+type exportedPeriod struct {
+	Commits          int              `json:"commits"`
+	UnknownDates     int              `json:"unknown_dates,omitempty"` // Counted per author only
+	Authors          []exportedAuthor `json:"authors"`                 // Most churn first
+	CommitsByMonth   []namedCount     `json:"commits_by_month"`
+	CommitsByWeekday []namedCount     `json:"commits_by_weekday"` // Monday first
+	CommitsByHour    [24]int          `json:"commits_by_hour"`    // Local time, indexed by hour
+}
+
+type exportedAuthor struct {
+	Name       string `json:"name"`
+	Commits    int    `json:"commits"`
+	Churn      int    `json:"churn"`
+	Additions  int    `json:"additions"`
+	Deletions  int    `json:"deletions"`
+	LastCommit string `json:"last_commit,omitempty"` // YYYY-MM-DD
+}
+
+type namedCount struct {
+	Name    string `json:"name"`
+	Commits int    `json:"commits"`
+}
+
+// exportDeveloperStats loads the whole history and writes its developer
+// stats to path as JSON.
+func exportDeveloperStats(cfg Config, path string) error {
+	commits := collectCommits(cfg)
+	grouping := authorGrouping{normalize: cfg.NormalizeAuthors}
+	doc := exportedStats{
+		Repo:        cfg.RepoPath,
+		GeneratedAt: time.Now(),
+		AllTime:     exportPeriod(commits, grouping),
+		Commits:     make([]exportedCommit, len(commits)),
+	}
+	for i, c := range commits {
+		doc.Commits[i] = exportedCommit{commitInfo: *c, ParentCount: c.ParentCount, ParentHashes: c.ParentHashes}
+	}
+
+	yearSet := make(map[int]bool)
+	for _, c := range commits {
+		if !hasUnknownDate(c) {
+			yearSet[c.Date.Year()] = true
+		}
+	}
+	years := make([]int, 0, len(yearSet))
+	for year := range yearSet {
+		years = append(years, year)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(years)))
+	for _, year := range years {
+		doc.Years = append(doc.Years, exportedYear{Year: year, exportedPeriod: exportPeriod(commitsInYear(commits, year), grouping)})
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal developer stats: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write developer stats: %v", err)
+	}
+	return nil
+}
+
+func exportPeriod(commits []*commitInfo, g authorGrouping) exportedPeriod {
+	stats := aggregateDeveloperStats(commits, g)
+	p := exportedPeriod{Commits: len(commits), UnknownDates: stats.unknownDates, Authors: []exportedAuthor{}}
+	for _, a := range topContributors(stats.authors, sortByChurn, len(stats.authors)) {
+		ea := exportedAuthor{Name: a.name, Commits: a.commits, Churn: a.churn, Additions: a.additions, Deletions: a.deletions}
+		if !a.last.IsZero() {
+			ea.LastCommit = a.last.Format("2006-01-02")
+		}
+		p.Authors = append(p.Authors, ea)
+	}
+	for month := time.January; month <= time.December; month++ {
+		p.CommitsByMonth = append(p.CommitsByMonth, namedCount{Name: month.String(), Commits: stats.months[month]})
+	}
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		p.CommitsByWeekday = append(p.CommitsByWeekday, namedCount{Name: day.String(), Commits: stats.weekdays[day]})
+	}
+	for hour := range p.CommitsByHour {
+		p.CommitsByHour[hour] = stats.hours[hour]
+	}
+	return p
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// fetchResult is one processed rev-list entry. info is nil when the commit was
+// filtered out; err and reason are set when it had to be skipped.
+type fetchResult struct {
+	seq    int
+	hash   string
+	info   *commitInfo
+	reason string
+	err    error
+}
+
+// fetchWindow bounds how far each worker may run ahead of the oldest commit
+// still in progress, which caps the reorder buffer.
+const fetchWindow = 4
+
+// fetchOrdered processes the hashes read from scanner on one goroutine per
+// repository handle and calls emit with the results in scanner order. emit
+// returns false to stop early; either way the workers have finished by the
+// time it returns.
+func (m *Model) fetchOrdered(scanner *bufio.Scanner, repos []*git.Repository, emit func(fetchResult) bool) {
+	type job struct {
+		seq  int
+		hash string
+	}
+	jobs := make(chan job)
+	results := make(chan fetchResult)
+	done := make(chan struct{})
+	window := make(chan struct{}, len(repos)*fetchWindow)
+
+	go func() {
+		defer close(jobs)
+		for seq := 0; scanner.Scan(); seq++ {
+			select {
+			case window <- struct{}{}:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- job{seq: seq, hash: scanner.Text()}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, r := range repos {
+		wg.Add(1)
+		go func(r *git.Repository) {
+			defer wg.Done()
+			for {
+				var j job
+				var ok bool
+				select {
+				case j, ok = <-jobs:
+				case <-done:
+				}
+				if !ok {
+					return
+				}
+				res := m.processCommit(r, j.hash)
+				res.seq = j.seq
+				if res.info != nil && m.config.LOCEvery > 0 && j.seq%m.config.LOCEvery == 0 {
+					m.sampleLOC(r, res.info)
+				}
+				select {
+				case results <- res:
+				case <-done:
+					return
+				}
+			}
+		}(r)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := make(map[int]fetchResult)
+	next := 0
+	for res := range results {
+		pending[res.seq] = res
+		for {
+			ready, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-window
+			if !emit(ready) {
+				close(done)
+				wg.Wait()
+				return
+			}
+		}
+	}
+}
+
+// processCommit reads one commit and computes its stats. It only touches r
+// and the commit itself, so it is safe to run on several commits at once.
+func (m *Model) processCommit(r *git.Repository, hashStr string) fetchResult {
+	res := fetchResult{hash: hashStr}
+	skip := func(reason string, err error) fetchResult {
+		res.reason, res.err = reason, err
+		return res
+	}
+
+	commit, err := r.CommitObject(plumbing.NewHash(hashStr))
+	if err != nil {
+		return skip("failed to read commit object", err)
+	}
+	signed := commit.PGPSignature != ""
+	if !m.config.signatureFilterAllows(signed) {
+		return res
+	}
+	if s, ok := m.statsCache.lookup(hashStr); ok {
+		res.info = newCommitInfo(commit, signed)
+		s.apply(res.info)
+		markNoise(m.config, res.info)
+		return res
+	}
+	paths, err := commitPathChanges(m.config.PathFilter, commit)
+	if err != nil {
+		return skip("failed to diff trees", err)
+	}
+
+	var fileStats []fileStat
+	if m.config.StatsSource == statsSourceGit {
+		if fileStats, err = gitFileStats(m.config.RepoPath, hashStr); err != nil {
+			return skip("failed to read numstat", err)
+		}
+	} else {
+		cTree, err := commit.Tree()
+		if err != nil {
+			return skip("failed to read tree", err)
+		}
+		// A root commit adds its whole tree, as git numstat --root counts it.
+		pTree := &object.Tree{}
+		if commit.NumParents() > 0 {
+			parent, err := commit.Parent(0)
+			if err != nil {
+				return skip("failed to read parent", err)
+			}
+			if pTree, err = parent.Tree(); err != nil {
+				return skip("failed to read parent tree", err)
+			}
+		}
+		var mayDiverge bool
+		if fileStats, mayDiverge, err = treeFileStats(pTree, cTree); err != nil {
+			return skip("failed to compute stats", err)
+		}
+		if mayDiverge {
+			crossCheckFileStats(m.config.RepoPath, hashStr, fileStats)
+		}
+	}
+	if len(m.config.PathFilter) > 0 {
+		// Commits that only touch other paths would show up as empty bars.
+		if fileStats = filterFileStats(m.config.PathFilter, fileStats); len(fileStats) == 0 {
+			return res
+		}
+	}
+	totals := sumFileStats(fileStats)
+	var changedPaths []string
+	if len(m.config.AlertPaths) > 0 {
+		for _, s := range fileStats {
+			changedPaths = append(changedPaths, s.Name)
+		}
+	}
+
+	res.info = newCommitInfo(commit, signed)
+	res.info.Paths = paths
+	res.info.AlertPaths = matchAlertPaths(m.config.AlertPaths, changedPaths)
+	res.info.DirChurn = dirChurn(fileStats)
+	res.info.FileStats = fileStats
+	res.info.FileStatsLoaded = true
+	res.info.Files = totals.files
+	res.info.Additions = totals.additions
+	res.info.Deletions = totals.deletions
+	res.info.Churn = computeChurn(m.config.ChurnMode, totals.additions, totals.deletions)
+	// Duplicates are only known once results are back in order, so noise is
+	// measured for every commit and cleared for duplicates afterwards.
+	markNoise(m.config, res.info)
+	return res
+}
+
+// newCommitInfo fills in what the timeline shows about commit apart from its
+// stats.
+func newCommitInfo(commit *object.Commit, signed bool) *commitInfo {
+	return &commitInfo{
+		Hash:         commit.Hash.String(),
+		Message:      commit.Message,
+		Author:       commit.Author.Name,
+		Committer:    commit.Committer.Name,
+		Date:         commit.Author.When,
+		Signed:       signed,
+		ParentHashes: parentHashes(commit),
+		ParentCount:  commit.NumParents(),
+	}
+}
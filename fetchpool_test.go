@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestFetchOrderedStopsEarly(t *testing.T) {
+	var history []fixtureCommit
+	for i := range 30 {
+		history = append(history, fixtureCommit{
+			author:  "Alice",
+			date:    fmt.Sprintf("2024-01-%02dT10:00:00Z", 1+i%28),
+			message: fmt.Sprintf("Commit %d", i),
+			write:   map[string]string{"n.txt": strings.Repeat("line\n", i+1)},
+		})
+	}
+	repo := newFixtureRepo(t, history)
+	out, err := exec.Command("git", "-C", repo, "rev-list", "--reverse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes := strings.Fields(string(out))
+
+	m := InitialModel(fixtureConfig(t, repo))
+	var repos []*git.Repository
+	for range 4 {
+		r, err := git.PlainOpen(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repos = append(repos, r)
+	}
+	var got []string
+	m.fetchOrdered(bufio.NewScanner(strings.NewReader(string(out))), repos, func(res fetchResult) bool {
+		got = append(got, res.hash)
+		return len(got) < 5
+	})
+	if !slices.Equal(got, hashes[:5]) {
+		t.Errorf("emitted %v, want the first five of %v", got, hashes)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// fileStatsLoadedMsg carries the per-file stats of a commit read by
+// fileStatsCmd.
+type fileStatsLoadedMsg struct {
+	commit *commitInfo
+	stats  []fileStat
+	err    error
+}
+
+// fileStatsCmd reads the per-file stats the stats panel needs but the fetcher
+// didn't keep, so View only ever shows what is already loaded: the current
+// commit's, then the previous commit's the file list compares against. One
+// commit is read at a time, through a repository handle of its own; the next
+// one starts when its result arrives.
+func (m *Model) fileStatsCmd() tea.Cmd {
+	if m.fileStatsLoading || len(m.commits) == 0 || m.config.FromJSON != "" {
+		return nil
+	}
+	i := m.currentCommitIndex
+	c := m.commits[i]
+	if c.FileStatsLoaded && i > 0 {
+		c = m.commits[i-1]
+	}
+	if c.FileStatsLoaded || m.fileStatsFailed[c.Hash] {
+		return nil
+	}
+	if m.fileStatsRepo == nil && !m.config.ReportMode && m.config.StatsSource != statsSourceGit {
+		r, err := git.PlainOpenWithOptions(m.config.RepoPath, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+		if err != nil {
+			slog.Warn("failed to open repository for file stats", "path", m.config.RepoPath, "err", err)
+			m.fileStatsFailed[c.Hash] = true
+			return nil
+		}
+		m.fileStatsRepo = r
+	}
+	m.fileStatsLoading = true
+	cfg, r := m.config, m.fileStatsRepo
+	return func() tea.Msg {
+		stats, err := readFileStats(cfg, r, c.Hash)
+		return fileStatsLoadedMsg{commit: c, stats: stats, err: err}
+	}
+}
+
+// setFileStats stores the result of fileStatsCmd on its commit.
+func (m *Model) setFileStats(msg fileStatsLoadedMsg) {
+	m.fileStatsLoading = false
+	if msg.err != nil {
+		slog.Warn("failed to read file stats", "hash", msg.commit.Hash, "err", msg.err)
+		m.fileStatsFailed[msg.commit.Hash] = true
+		return
+	}
+	msg.commit.FileStats, msg.commit.FileStatsLoaded = msg.stats, true
+}
+
+// readFileStats computes the per-file stats of a commit from the configured
+// source, filtered by -path. Report mode totals come from git numstat, so its
+// file lists do too.
+func readFileStats(cfg Config, r *git.Repository, hash string) ([]fileStat, error) {
+	if cfg.ReportMode || cfg.StatsSource == statsSourceGit {
+		stats, err := gitFileStats(cfg.RepoPath, hash)
+		if err != nil {
+			return nil, err
+		}
+		return filterFileStats(cfg.PathFilter, stats), nil
+	}
+	if r == nil {
+		return nil, fmt.Errorf("no repository loaded")
+	}
+
+	commitObject, err := r.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	cTree, err := commitObject.Tree()
+	if err != nil {
+		return nil, err
+	}
+	pTree := &object.Tree{}
+	if commitObject.NumParents() > 0 {
+		parent, err := commitObject.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if pTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	stats, mayDiverge, err := treeFileStats(pTree, cTree)
+	if err != nil {
+		return nil, err
+	}
+	if mayDiverge {
+		crossCheckFileStats(cfg.RepoPath, hash, stats)
+	}
+	return filterFileStats(cfg.PathFilter, stats), nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestFileStatsCmdLoadsCurrentThenPrevious(t *testing.T) {
+	cfg := fixtureConfig(t, newFixtureRepo(t, textHistory))
+	m := InitialModel(cfg)
+	for _, c := range collectCommits(cfg) {
+		c.FileStats, c.FileStatsLoaded = nil, false
+		m.appendCommit(c)
+	}
+	m.loadingComplete = true
+	m.currentCommitIndex = 1
+	current, previous := m.commits[1], m.commits[0]
+
+	for _, want := range []*commitInfo{current, previous} {
+		if m.previousFileSet(current) != nil {
+			t.Fatal("previous files compared before they were loaded")
+		}
+		cmd := m.fileStatsCmd()
+		if cmd == nil {
+			t.Fatalf("nothing loaded for %q", want.Message)
+		}
+		msg := cmd().(fileStatsLoadedMsg)
+		if msg.commit != want {
+			t.Fatalf("loaded %q, want %q", msg.commit.Message, want.Message)
+		}
+		m.setFileStats(msg)
+	}
+	if m.fileStatsCmd() != nil {
+		t.Error("more file stats loaded once both commits had theirs")
+	}
+	if set := m.previousFileSet(current); !set["README.md"] || !set["main.go"] || len(set) != 2 {
+		t.Errorf("previous files = %v, want README.md and main.go", set)
+	}
+}
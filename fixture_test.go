@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureCommit is one commit of a test repository. Renames run before
+// removals and writes.
+type fixtureCommit struct {
+	author  string
+	date    string // Any date git accepts, used for both author and committer
+	message string
+	rename  map[string]string // old path -> new path
+	remove  []string
+	write   map[string]string // path -> contents
+}
+
+// fixtureHistory covers what the stats paths treat specially: a root commit,
+// a binary file, a rename with an edit, a deletion and an empty file.
+var fixtureHistory = []fixtureCommit{
+	{
+		author:  "Alice",
+		date:    "2024-01-01T10:00:00Z",
+		message: "Initial import",
+		write: map[string]string{
+			"README.md": "# Fixture\n\nA small repository.\n",
+			"main.go":   "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+			"logo.png":  "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x01",
+		},
+	},
+	{
+		author:  "Bob",
+		date:    "2024-01-02T11:30:00Z",
+		message: "Greet the world",
+		write: map[string]string{
+			"main.go": "package main\n\nfunc main() {\n\tprintln(\"hello\")\n\tprintln(\"world\")\n}\n",
+		},
+	},
+	{
+		author:  "Alice",
+		date:    "2024-01-05T09:15:00Z",
+		message: "Move the readme under docs",
+		rename:  map[string]string{"README.md": "docs/README.md"},
+		write: map[string]string{
+			"docs/README.md": "# Fixture\n\nA small repository.\nNow with docs.\n",
+		},
+	},
+	{
+		author:  "Bob",
+		date:    "2024-02-01T16:45:00Z",
+		message: "Drop the logo and add helpers",
+		remove:  []string{"logo.png"},
+		write: map[string]string{
+			"util.go":  "package main\n\nfunc add(a, b int) int { return a + b }\n",
+			".keep":    "",
+			"data.bin": "\x00\x01\x02\x03",
+		},
+	},
+	{
+		author:  "Alice",
+		date:    "2025-03-01T08:00:00Z",
+		message: "Subtract too",
+		write: map[string]string{
+			"util.go":  "package main\n\nfunc add(a, b int) int { return a + b }\n\nfunc sub(a, b int) int { return a - b }\n",
+			"data.bin": "\x00\x01\x02\x04\x05",
+		},
+	},
+}
+
+// newFixtureRepo creates a git repository in a temporary directory with the
+// given commits and returns its path.
+func newFixtureRepo(tb testing.TB, commits []fixtureCommit) string {
+	tb.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		tb.Skip("git not found")
+	}
+	dir := tb.TempDir()
+	git := func(env []string, args ...string) {
+		tb.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL="+os.DevNull, "GIT_CONFIG_NOSYSTEM=1")
+		cmd.Env = append(cmd.Env, env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			tb.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git(nil, "init", "-q", "-b", "main")
+	for _, c := range commits {
+		for from, to := range c.rename {
+			if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(to)), 0o755); err != nil {
+				tb.Fatal(err)
+			}
+			git(nil, "mv", from, to)
+		}
+		for _, name := range c.remove {
+			git(nil, "rm", "-q", name)
+		}
+		for name, contents := range c.write {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				tb.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+		git(nil, "add", "-A")
+		email := c.author + "@example.com"
+		git([]string{
+			"GIT_AUTHOR_NAME=" + c.author, "GIT_AUTHOR_EMAIL=" + email, "GIT_AUTHOR_DATE=" + c.date,
+			"GIT_COMMITTER_NAME=" + c.author, "GIT_COMMITTER_EMAIL=" + email, "GIT_COMMITTER_DATE=" + c.date,
+		}, "commit", "-q", "--allow-empty", "--no-gpg-sign", "-m", c.message)
+	}
+	return dir
+}
+
+// fixtureConfig returns the default configuration pointed at repo, without
+// the stats cache so runs don't leave files behind. Any .visagit.yml in the
+// working directory is ignored.
+func fixtureConfig(tb testing.TB, repo string) Config {
+	tb.Helper()
+	cfg := defaultConfig()
+	cfg.RepoPath = repo
+	cfg.NoCache = true
+	return cfg
+}
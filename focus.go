@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// focusedAuthor returns the -focus-author's stats among authors, or nil when
+// no author is focused or they have no commits in the period yet.
+func (m *Model) focusedAuthor(authors map[string]*authorStat) *authorStat {
+	if m.config.FocusAuthor == "" {
+		return nil
+	}
+	return authors[authorKeyFor(m.config.FocusAuthor, m.config.NormalizeAuthors)]
+}
+
+// renderFocusedAuthor stands in for the top-5 list with one author's stats
+// and a sparkline of their commits over the period.
+func (m *Model) renderFocusedAuthor(a *authorStat, stats *developerStats, width int) string {
+	header := trf("Focus: %s", truncateMessage(a.name, 32))
+	if m.displayedStatsYear == 0 {
+		header += tr(" (All-Time)")
+	} else {
+		header += fmt.Sprintf(" (%d)", m.displayedStatsYear)
+	}
+
+	total := 0
+	for _, s := range stats.authors {
+		total += s.commits
+	}
+	rank := 0
+	for i, s := range topContributors(stats.authors, m.contributorSort, len(stats.authors)) {
+		if s.key == a.key {
+			rank = i + 1
+			break
+		}
+	}
+	last := unknownDateLabel
+	if !a.last.IsZero() {
+		last = a.last.Format("2006-01-02")
+	}
+
+	commits := m.statsCommits()
+	from, to := m.statsPeriod(commits)
+	cadence := make([]int, width)
+	maxCadence := 0
+	grouping := m.authorGrouping()
+	for _, c := range commits {
+		if hasUnknownDate(c) || grouping.keyOf(c) != a.key {
+			continue
+		}
+		slot := cadenceSlot(c.Date, from, to, width)
+		cadence[slot]++
+		maxCadence = max(maxCadence, cadence[slot])
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(header) + "\n")
+	b.WriteString(fmt.Sprintf(" %-12s %d\n", tr("churn"), a.churn))
+	b.WriteString(fmt.Sprintf(" %-12s %d (%d%%)\n", tr("commits"), a.commits, a.commits*100/max(total, 1)))
+	b.WriteString(fmt.Sprintf(" %-12s %s\n", tr("additions"), additionStyle.Render(fmt.Sprintf("+%d", a.additions))))
+	b.WriteString(fmt.Sprintf(" %-12s %s\n", tr("deletions"), deletionStyle.Render(fmt.Sprintf("-%d", a.deletions))))
+	b.WriteString(fmt.Sprintf(" %-12s %s\n", tr("last commit"), last))
+	b.WriteString(fmt.Sprintf(" %-12s #%d/%d%s\n", tr("rank"), rank, len(stats.authors), trf(" by %s", tr(contributorSortNames[m.contributorSort]))))
+	b.WriteString(" " + graphAxisStyle.Render(tr("Cadence")) + "\n")
+	b.WriteString(" " + barStyle.Render(sparkline(cadence, maxCadence)) + "\n")
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// loadCommitsJSON reads commits from a file written by -export-json, or by
+// -output json, whose parent fields are only there with -export-parents.
+func loadCommitsJSON(path string) ([]*commitInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	var exported []exportedCommit
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(data, &exported)
+	} else {
+		var doc exportedStats
+		if err = json.Unmarshal(data, &doc); err == nil && doc.Commits == nil {
+			err = fmt.Errorf("no commits, export it again with -export-json")
+		}
+		exported = doc.Commits
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	commits := make([]*commitInfo, len(exported))
+	for i := range exported {
+		c := exported[i].commitInfo
+		// The timeline and diffs slice hashes, so a short one would panic.
+		if !plumbing.IsHash(c.Hash) {
+			return nil, fmt.Errorf("failed to parse %s: commit %d has an invalid hash %q", path, i, c.Hash)
+		}
+		c.ParentCount, c.ParentHashes = exported[i].ParentCount, exported[i].ParentHashes
+		commits[i] = &c
+	}
+	return commits, nil
+}
+
+// loadFromJSONCmd replaces the fetcher when -from-json is set. The repository
+// is still opened for diffs, but its absence only disables them.
+func (m *Model) loadFromJSONCmd() tea.Cmd {
+	return func() tea.Msg {
+		commits, err := loadCommitsJSON(m.config.FromJSON)
+		if err != nil {
+			return errMsg{err}
+		}
+		repo, err := git.PlainOpenWithOptions(m.config.RepoPath, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+		if err != nil {
+			slog.Warn("repository not available, diffs disabled", "path", m.config.RepoPath, "err", err)
+			repo = nil
+		}
+		slog.Info("loaded commits from json", "path", m.config.FromJSON, "commits", len(commits))
+		return reportLoadedMsg{repo: repo, commits: commits, total: len(commits), engine: "json"}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportJSONRoundTrip(t *testing.T) {
+	cfg := fixtureConfig(t, newFixtureRepo(t, fixtureHistory))
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := exportDeveloperStats(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadCommitsJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := collectCommits(cfg)
+	if len(loaded) != len(want) {
+		t.Fatalf("loaded %d commits, want %d", len(loaded), len(want))
+	}
+	for i, got := range loaded {
+		w := want[i]
+		if got.Hash != w.Hash || got.Author != w.Author || !got.Date.Equal(w.Date) ||
+			got.Additions != w.Additions || got.Deletions != w.Deletions || got.Files != w.Files ||
+			got.CumulativeAdditions != w.CumulativeAdditions || got.ParentCount != w.ParentCount ||
+			!reflect.DeepEqual(got.ParentHashes, w.ParentHashes) {
+			t.Errorf("commit %d = %+v, want %+v", i, *got, *w)
+		}
+	}
+}
+
+func TestLoadCommitsJSONRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"short hash", `[{"hash": "abc"}]`, `invalid hash "abc"`},
+		{"stats export without commits", `{"repo": ".", "years": []}`, "no commits"},
+		{"not json", `hash,author`, "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "commits.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := loadCommitsJSON(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
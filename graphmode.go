@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Changes graph modes, selected with the graphMode config and cycled with %.
+const (
+	graphModeLog          = "log"          // Bar height grows with the log of the line counts
+	graphModeProportional = "proportional" // Every bar has the same length, split by the additions/deletions ratio
+	graphModeSize         = "size"         // Lines of code over time; needs -loc-every
+	graphModeCadence      = "cadence"      // Churn bars with a commits-per-week line
+)
+
+var graphModes = []string{graphModeLog, graphModeProportional, graphModeCadence, graphModeSize}
+
+// cycleGraphMode moves to the next graph mode, passing over size when there
+// are no LOC samples to plot.
+func (m *Model) cycleGraphMode() {
+	m.graphMode = graphModes[(indexOf(graphModes, m.graphMode)+1)%len(graphModes)]
+	if m.graphMode == graphModeSize && m.config.LOCEvery <= 0 {
+		m.cycleGraphMode()
+	}
+}
+
+// scaleProportional splits span pixels between additions and deletions by
+// their share of the commit's changed lines.
+func scaleProportional(additions, deletions, span int) (int, int) {
+	total := additions + deletions
+	if total == 0 {
+		return 0, 0
+	}
+	up := (additions*span + total/2) / total
+	return up, span - up
+}
+
+// zeroLineSplits are the additions shares, in percent of the graph height,
+// that z cycles through.
+var zeroLineSplits = []int{50, 70, 30}
+
+// cycleZeroLine moves the changes graph's zero line to the next split.
+func (m *Model) cycleZeroLine() {
+	m.additionsShare = zeroLineSplits[(slices.Index(zeroLineSplits, m.additionsShare)+1)%len(zeroLineSplits)]
+	m.notice = fmt.Sprintf("zero line: %d%% additions / %d%% deletions", m.additionsShare, 100-m.additionsShare)
+}
+
+// zeroLine is the pixel row of the changes graph's zero line on a canvas
+// height pixels tall. The additions get their share of the height whichever
+// side they are drawn on.
+func (m *Model) zeroLine(height int) int {
+	upper := m.additionsShare
+	if m.deletionsOnTop {
+		upper = 100 - upper
+	}
+	return max(1, min(height*upper/100, height-2))
+}
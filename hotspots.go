@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fileHotspot accumulates how often and how heavily a single file changed.
+type fileHotspot struct {
+	path      string
+	commits   int
+	additions int
+	deletions int
+}
+
+func (h fileHotspot) churn(mode string) int { return computeChurn(mode, h.additions, h.deletions) }
+
+// collectHotspots sums per-file numstat output over the full history, most
+// churned first. -limit is not applied here.
+func collectHotspots(cfg Config) ([]fileHotspot, error) {
+	args := []string{
+		"-C", cfg.RepoPath,
+		"log",
+		"--numstat",
+		"--no-renames",
+		"--no-color",
+		"--pretty=format:%H",
+	}
+	args = append(args, cfg.revisionRange())
+	args = append(args, cfg.pathspec()...)
+
+	cmd := exec.Command("git", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe for git log numstat: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start git log numstat: %v", err)
+	}
+	slog.Debug("started subprocess", "args", cmd.Args, "pid", cmd.Process.Pid)
+
+	byPath := make(map[string]*fileHotspot)
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		h, ok := byPath[fields[2]]
+		if !ok {
+			h = &fileHotspot{path: fields[2]}
+			byPath[fields[2]] = h
+		}
+		h.commits++
+		add, del := 0, 0
+		if fields[0] != "-" {
+			fmt.Sscanf(fields[0], "%d", &add)
+		}
+		if fields[1] != "-" {
+			fmt.Sscanf(fields[1], "%d", &del)
+		}
+		h.additions += add
+		h.deletions += del
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("git log numstat scan failed: %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git log numstat failed: %v", err)
+	}
+	slog.Debug("subprocess finished", "args", cmd.Args, "files", len(byPath))
+
+	hotspots := make([]fileHotspot, 0, len(byPath))
+	for _, h := range byPath {
+		hotspots = append(hotspots, *h)
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		ci, cj := hotspots[i].churn(cfg.ChurnMode), hotspots[j].churn(cfg.ChurnMode)
+		if ci != cj {
+			return ci > cj
+		}
+		return hotspots[i].path < hotspots[j].path
+	})
+	return hotspots, nil
+}
+
+func exportHotspots(cfg Config, path string) error {
+	hotspots, err := collectHotspots(cfg)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create hotspot export: %v", err)
+	}
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"file", "commits", "additions", "deletions", "churn"})
+	for _, h := range hotspots {
+		w.Write([]string{
+			h.path,
+			strconv.Itoa(h.commits),
+			strconv.Itoa(h.additions),
+			strconv.Itoa(h.deletions),
+			strconv.Itoa(h.churn(cfg.ChurnMode)),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write hotspot export: %v", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestCollectHotspotsUsesChurnMode(t *testing.T) {
+	repo := newFixtureRepo(t, []fixtureCommit{
+		{author: "Alice", date: "2024-01-01T10:00:00Z", message: "Add", write: map[string]string{"a.txt": "1\n2\n3\n"}},
+		{author: "Alice", date: "2024-01-02T10:00:00Z", message: "Trim", write: map[string]string{"a.txt": "1\n"}},
+	})
+	for mode, want := range map[string]int{churnSum: 5, churnMax: 3, churnNet: 1, churnAdditions: 3} {
+		cfg := fixtureConfig(t, repo)
+		cfg.ChurnMode = mode
+		hotspots, err := collectHotspots(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hotspots) != 1 {
+			t.Fatalf("got %d hotspots, want 1", len(hotspots))
+		}
+		if got := hotspots[0].churn(cfg.ChurnMode); got != want {
+			t.Errorf("churn with mode %s = %d, want %d", mode, got, want)
+		}
+	}
+}
+
+func TestCollectHotspotsIgnoresCommitLimit(t *testing.T) {
+	cfg := fixtureConfig(t, newFixtureRepo(t, textHistory))
+	cfg.CommitLimit = 1
+	hotspots, err := collectHotspots(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commits := 0
+	for _, h := range hotspots {
+		commits = max(commits, h.commits)
+	}
+	if commits < 2 {
+		t.Errorf("most-changed file has %d commits, want the full history counted", commits)
+	}
+}
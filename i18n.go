@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// catalogs holds the UI translations selectable with -lang. The English
+// strings double as message keys, so a catalog only lists what it translates
+// and anything missing falls back to English. To add a language, add a
+// catalog here.
+var catalogs = map[string]map[string]string{
+	"en": {},
+	"sv": {
+		"Commit & Project Stats":  "Commit- och projektstatistik",
+		"Commit Changes":          "Ändringar i commit",
+		"Churn by Directory":      "Churn per katalog",
+		"Commit Timeline":         "Committidslinje",
+		"Developer Stats":         "Utvecklarstatistik",
+		"Longest-Lived Files":     "Mest långlivade filer",
+		"Loading commits...":      "Läser in commits...",
+		"No commits":              "Inga commits",
+		"Not enough space":        "Inte tillräckligt med utrymme",
+		"Author: %s":              "Författare: %s",
+		"Date: %s":                "Datum: %s",
+		"Commits:":                "Commits:",
+		"Authors:":                "Författare:",
+		"Merges:":                 "Sammanslagningar:",
+		"Additions:":              "Tillägg:",
+		"Deletions:":              "Borttag:",
+		"Commit adds:":            "Tillagt:",
+		"Commit dels:":            "Borttaget:",
+		"Alerts:":                 "Varningar:",
+		"Duplicates:":             "Dubbletter:",
+		"Noise:":                  "Brus:",
+		"Speed:":                  "Hastighet:",
+		"Lines:":                  "Rader:",
+		"Top 5 (All-Time)":        "Topp 5 (totalt)",
+		"Top 5 (%d)":              "Topp 5 (%d)",
+		" by %s":                  " efter %s",
+		" (committers)":           " (committers)",
+		"churn":                   "churn",
+		"commits":                 "commits",
+		"additions":               "tillägg",
+		"deletions":               "borttag",
+		"last commit":             "senaste commit",
+		"Busy Days (>%d commits)": "Intensiva dagar (>%d commits)",
+		"Commits by Month":        "Commits per månad",
+		"Commits by Weekday":      "Commits per veckodag",
+		"Commits by Hour (Local)": "Commits per timme (lokal tid)",
+		"Compare (All-Time)":      "Jämförelse (totalt)",
+		"Focus: %s":               "Fokus: %s",
+		" (All-Time)":             " (totalt)",
+		"rank":                    "placering",
+		"No commits by %s yet":    "Inga commits av %s ännu",
+		"Compare (%d)":            "Jämförelse (%d)",
+		"No commits in %d":        "Inga commits under %d",
+		"No commits yet":          "Inga commits ännu",
+		"Cadence":                 "Takt",
+		"By weekday":              "Per veckodag",
+		"By hour (local)":         "Per timme (lokal tid)",
+		"January":                 "januari",
+		"February":                "februari",
+		"March":                   "mars",
+		"April":                   "april",
+		"May":                     "maj",
+		"June":                    "juni",
+		"July":                    "juli",
+		"August":                  "augusti",
+		"September":               "september",
+		"October":                 "oktober",
+		"November":                "november",
+		"December":                "december",
+		"Monday":                  "måndag",
+		"Tuesday":                 "tisdag",
+		"Wednesday":               "onsdag",
+		"Thursday":                "torsdag",
+		"Friday":                  "fredag",
+		"Saturday":                "lördag",
+		"Sunday":                  "söndag",
+
+		"%d commits skipped (errors)": "%d commits överhoppade (fel)",
+		"Files unavailable":           "Filer ej tillgängliga",
+		"Loading files...":            "Läser in filer...",
+		"No files changed":            "Inga filer ändrade",
+		"+%d more":                    "+%d till",
+		"... and %d more":             "... och %d till",
+		"Oldest files still present":  "Äldsta filer som finns kvar",
+		"File history unavailable":    "Filhistorik ej tillgänglig",
+		"No file history":             "Ingen filhistorik",
+	},
+}
+
+var activeCatalog = catalogs["en"]
+
+// setLanguage selects the catalog used by tr.
+func setLanguage(lang string) error {
+	catalog, ok := catalogs[lang]
+	if !ok {
+		langs := make([]string, 0, len(catalogs))
+		for l := range catalogs {
+			langs = append(langs, l)
+		}
+		sort.Strings(langs)
+		return fmt.Errorf("unsupported lang: %s. supported languages are: %s", lang, strings.Join(langs, ", "))
+	}
+	activeCatalog = catalog
+	return nil
+}
+
+// tr translates a UI string, returning it unchanged when the active catalog has
+// no entry.
+func tr(s string) string {
+	if t, ok := activeCatalog[s]; ok {
+		return t
+	}
+	return s
+}
+
+// trf translates a format string before applying it.
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+func monthName(month time.Month) string   { return tr(month.String()) }
+func weekdayName(day time.Weekday) string { return tr(day.String()) }
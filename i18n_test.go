@@ -0,0 +1,66 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestCatalogsCoverUIStrings checks that every string literal passed to tr or
+// trf, and every contributor sort name, has an entry in each non-English
+// catalog.
+func TestCatalogsCoverUIStrings(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]string{} // key -> position of first use
+	for _, name := range contributorSortNames {
+		keys[name] = "contributorSortNames"
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			fn, ok := call.Fun.(*ast.Ident)
+			if !ok || (fn.Name != "tr" && fn.Name != "trf") {
+				return true
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				s, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, seen := keys[s]; !seen {
+					keys[s] = fset.Position(lit.Pos()).String()
+				}
+			}
+			return true
+		})
+	}
+
+	for lang, catalog := range catalogs {
+		if lang == "en" {
+			continue
+		}
+		for key, pos := range keys {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s catalog has no entry for %q (%s)", lang, key, pos)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Idle actions, selected with the idleAction config.
+const (
+	idleActionReplay     = "replay"      // Loop playback over the loaded commits
+	idleActionCycleYears = "cycle-years" // Rotate the developer stats through each year
+)
+
+// idleTick starts the configured idle action once the dashboard has been paused
+// without input for IdleSeconds, and advances it while it runs.
+func (m *Model) idleTick(now time.Time) {
+	if m.config.IdleSeconds <= 0 || m.diffState == inDiffView || len(m.commits) == 0 {
+		return
+	}
+	if !m.idleActive {
+		// Only kick in when nothing is moving: paused, or playback caught up.
+		moving := m.autoProgress && (!m.loadingComplete || m.playbackBehind())
+		if moving || now.Sub(m.lastInput) < time.Duration(m.config.IdleSeconds)*time.Second {
+			return
+		}
+		m.idleActive = true
+		m.idleStep = now
+		m.idleSavedIndex = m.currentCommitIndex
+		m.idleSavedYearIndex = m.currentStatYearIndex
+		m.idleSavedAuto = m.autoProgress
+		if m.config.IdleAction != idleActionCycleYears && !m.loadingComplete {
+			m.autoProgress = true
+		}
+		return
+	}
+
+	switch m.config.IdleAction {
+	case idleActionCycleYears:
+		if now.Sub(m.idleStep) < time.Duration(max(1, m.config.IdleCycleSeconds))*time.Second {
+			return
+		}
+		m.idleStep = now
+		if len(m.availableStatYears) > 0 {
+			m.currentStatYearIndex = (m.currentStatYearIndex + 1) % len(m.availableStatYears)
+			m.displayedStatsYear = m.availableStatYears[m.currentStatYearIndex]
+		}
+	default:
+		if m.loadingComplete {
+			m.currentCommitIndex = (m.currentCommitIndex + 1) % len(m.commits)
+		}
+	}
+}
+
+// noteInput records user activity. It reports true when the input cancelled a
+// running idle action, in which case the key should not be handled further.
+func (m *Model) noteInput(now time.Time) bool {
+	m.lastInput = now
+	if !m.idleActive {
+		return false
+	}
+	m.idleActive = false
+	m.autoProgress = m.idleSavedAuto
+	if m.config.IdleAction == idleActionCycleYears {
+		m.currentStatYearIndex = m.idleSavedYearIndex
+		if m.currentStatYearIndex < len(m.availableStatYears) {
+			m.displayedStatsYear = m.availableStatYears[m.currentStatYearIndex]
+		}
+	} else if m.loadingComplete && m.idleSavedIndex < len(m.commits) {
+		m.currentCommitIndex = m.idleSavedIndex
+	}
+	return true
+}
+
+// shouldStopTicks reports whether nothing can change without input: playback
+// paused, loading finished, no screensaver configured and no input for
+// IdlePauseSeconds. Ticks then stop until the next key or resize.
+func (m *Model) shouldStopTicks(now time.Time) bool {
+	if m.config.IdlePauseSeconds <= 0 || m.config.IdleSeconds > 0 {
+		return false
+	}
+	if m.autoProgress || !m.loadingComplete {
+		return false
+	}
+	return now.Sub(m.lastInput) >= time.Duration(m.config.IdlePauseSeconds)*time.Second
+}
+
+// wakeTicks restarts stopped ticks on input. It reports whether the caller
+// must schedule the next tick.
+func (m *Model) wakeTicks(msg tea.Msg) bool {
+	if !m.ticksStopped {
+		return false
+	}
+	switch msg.(type) {
+	case tea.KeyPressMsg, tea.WindowSizeMsg:
+		m.ticksStopped = false
+		return true
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// clockedModel returns a paused, fully loaded model whose clock reads *now.
+func clockedModel(t *testing.T, cfg func(*Config)) (*Model, *time.Time) {
+	t.Helper()
+	config := fixtureConfig(t, "")
+	cfg(&config)
+	m := InitialModel(config)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	m.nowFunc = func() time.Time { return now }
+	for i := range 5 {
+		m.appendCommit(&commitInfo{Hash: fmt.Sprintf("%040d", i), Author: "Alice", Date: now.AddDate(0, 0, i-5)})
+	}
+	m.loadingComplete = true
+	m.autoProgress = false
+	m.currentCommitIndex = 2
+	m.lastInput = now
+	return &m, &now
+}
+
+func TestIdleReplayFollowsModelClock(t *testing.T) {
+	m, now := clockedModel(t, func(c *Config) {
+		c.IdleSeconds = 30
+		c.IdleAction = idleActionReplay
+	})
+	tick := func(after time.Duration) {
+		*now = now.Add(after)
+		// The tick's own time is ignored in favour of the model clock.
+		m.Update(progressTickMsg(time.Time{}))
+	}
+
+	tick(29 * time.Second)
+	if m.idleActive {
+		t.Fatal("idle after 29s, want 30s")
+	}
+	tick(time.Second)
+	if !m.idleActive {
+		t.Fatal("not idle after 30s")
+	}
+	tick(time.Second)
+	if m.currentCommitIndex != 3 {
+		t.Errorf("replay at commit %d, want 3", m.currentCommitIndex)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m.idleActive || m.currentCommitIndex != 2 {
+		t.Errorf("after a key: idle %v at commit %d, want stopped back at 2", m.idleActive, m.currentCommitIndex)
+	}
+	if !m.lastInput.Equal(*now) {
+		t.Errorf("last input at %v, want the model clock's %v", m.lastInput, *now)
+	}
+}
+
+func TestTicksStopAfterIdlePause(t *testing.T) {
+	m, now := clockedModel(t, func(c *Config) {
+		c.IdleSeconds = 0
+		c.IdlePauseSeconds = 60
+	})
+
+	*now = now.Add(59 * time.Second)
+	m.Update(progressTickMsg(time.Time{}))
+	if m.ticksStopped {
+		t.Fatal("ticks stopped after 59s, want 60s")
+	}
+	*now = now.Add(time.Second)
+	m.Update(progressTickMsg(time.Time{}))
+	if !m.ticksStopped {
+		t.Fatal("ticks still running after 60s without input")
+	}
+
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: 'k', Text: "k"}); m.ticksStopped || cmd == nil {
+		t.Error("a key didn't restart the ticks")
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+// Initial commit handling, selected with the initialCommit config. Root commits
+// usually add the whole tree and would otherwise flatten the changes graph.
+const (
+	initialCommitInclude = "include" // Scale and draw root commits like any other
+	initialCommitExclude = "exclude" // Leave root commits out of the graph scale; bars are clipped
+	initialCommitHide    = "hide"    // Leave root commits out of the scale and the graph
+)
+
+// scalesCommit reports whether c counts towards maxAdditions/maxDeletions.
+// Cumulative totals always include every commit.
+func (m *Model) scalesCommit(c *commitInfo) bool {
+	return m.config.InitialCommit == initialCommitInclude || len(c.ParentHashes) > 0
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Actions that can be bound to keys via the keybindings config section.
+const (
+	actionQuit             = "quit"
+	actionNext             = "next"
+	actionPrev             = "prev"
+	actionYearPrev         = "yearPrev"
+	actionYearNext         = "yearNext"
+	actionToggleAuto       = "toggleAuto"
+	actionEnterDiff        = "enterDiff"
+	actionToggleGraph      = "toggleGraph"
+	actionExitDiff         = "exitDiff"
+	actionScrollUp         = "scrollUp"
+	actionScrollDown       = "scrollDown"
+	actionPageUp           = "pageUp"
+	actionPageDown         = "pageDown"
+	actionMoreContext      = "moreContext"
+	actionLessContext      = "lessContext"
+	actionToggleWhitespace = "toggleWhitespace"
+	actionMarkA            = "markA"
+	actionMarkB            = "markB"
+	actionCompareDiff      = "compareDiff"
+	actionToggleDirs       = "toggleDirs"
+	actionFlipGraph        = "flipGraph"
+	actionCycleSort        = "cycleSort"
+	actionToggleLifetimes  = "toggleLifetimes"
+	actionToggleStatsMode  = "toggleStatsMode"
+	actionOpenPager        = "openPager"
+	actionSaveDiff         = "saveDiff"
+	actionNextAnnotation   = "nextAnnotation"
+	actionPrevAnnotation   = "prevAnnotation"
+	actionToggleIdentity   = "toggleIdentity"
+	actionCatchUp          = "catchUp"
+	actionCatchUpPlay      = "catchUpPlay"
+	actionCycleGraphMode   = "cycleGraphMode"
+	actionToggleFullHash   = "toggleFullHash"
+	actionDumpState        = "dumpState"
+	actionNextPanel        = "nextPanel"
+	actionPrevPanel        = "prevPanel"
+	actionSpeedUp          = "speedUp"
+	actionSlowDown         = "slowDown"
+	actionSeekDecile       = "seekDecile"
+	actionFirst            = "first"
+	actionLast             = "last"
+	actionToggleEOL        = "toggleEol"
+	actionDaySeparators    = "toggleDaySeparators"
+	actionNextAuthor       = "nextAuthor"
+	actionPrevAuthor       = "prevAuthor"
+	actionPickAuthor       = "pickAuthor"
+	actionSearch           = "search"
+	actionNextMatch        = "nextMatch"
+	actionPrevMatch        = "prevMatch"
+	actionToggleSplit      = "toggleSplit"
+	actionCycleZeroLine    = "cycleZeroLine"
+	actionNextFile         = "nextFile"
+	actionPrevFile         = "prevFile"
+	actionToggleWrap       = "toggleWrap"
+	actionLineNumbers      = "toggleLineNumbers"
+)
+
+// Default bindings for the dashboard.
+var defaultMainKeys = map[string][]string{
+	actionQuit:            {"q", "ctrl+c"},
+	actionNext:            {"right", "l"},
+	actionPrev:            {"left", "h"},
+	actionYearPrev:        {"up", "k"},
+	actionYearNext:        {"down", "j"},
+	actionToggleAuto:      {"p", "space"},
+	actionEnterDiff:       {"enter"},
+	actionToggleGraph:     {"t"},
+	actionMarkA:           {"a"},
+	actionMarkB:           {"b"},
+	actionCompareDiff:     {"c"},
+	actionToggleDirs:      {"d"},
+	actionFlipGraph:       {"f"},
+	actionCycleSort:       {"s"},
+	actionToggleLifetimes: {"o"},
+	actionToggleStatsMode: {"v"},
+	actionNextAnnotation:  {"n"},
+	actionPrevAnnotation:  {"N"},
+	actionToggleIdentity:  {"u"},
+	actionCatchUp:         {"e"},
+	actionCatchUpPlay:     {"E"},
+	actionCycleGraphMode:  {"%"},
+	actionToggleFullHash:  {"H"},
+	actionDumpState:       {"ctrl+d"},
+	actionNextPanel:       {"tab"},
+	actionPrevPanel:       {"shift+tab"},
+	actionSpeedUp:         {"+", "=", "]"},
+	actionSlowDown:        {"-", "["},
+	actionSeekDecile:      {"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+	actionFirst:           {"g"},
+	actionLast:            {"G"},
+	actionDaySeparators:   {"D"},
+	actionNextAuthor:      {"J"},
+	actionPrevAuthor:      {"K"},
+	actionPickAuthor:      {"x"},
+	actionCycleZeroLine:   {"z"},
+}
+
+// Default bindings for the diff view.
+var defaultDiffKeys = map[string][]string{
+	actionExitDiff:         {"q", "ctrl+c", "esc", "enter"},
+	actionScrollUp:         {"up", "k"},
+	actionScrollDown:       {"down", "j"},
+	actionPageUp:           {"pgup"},
+	actionPageDown:         {"pgdown", "space"},
+	actionMoreContext:      {"+", "="},
+	actionLessContext:      {"-"},
+	actionToggleWhitespace: {"i"},
+	actionNext:             {"right", "l"},
+	actionPrev:             {"left", "h"},
+	actionOpenPager:        {"|"},
+	actionSaveDiff:         {"s"},
+	actionToggleEOL:        {"r"},
+	actionSearch:           {"/"},
+	actionNextMatch:        {"n"},
+	actionPrevMatch:        {"N"},
+	actionToggleSplit:      {"S"},
+	actionNextFile:         {"]"},
+	actionPrevFile:         {"["},
+	actionToggleWrap:       {"w"},
+	actionLineNumbers:      {"#"},
+}
+
+// keyMap resolves pressed keys to actions for each view.
+type keyMap struct {
+	main map[string]string // key -> action
+	diff map[string]string // key -> action
+}
+
+func (k keyMap) mainAction(key string) string { return k.main[key] }
+func (k keyMap) diffAction(key string) string { return k.diff[key] }
+
+// newKeyMap applies the configured overrides to the default bindings. An override
+// replaces every default key for that action. Unknown actions and keys bound to
+// more than one action in the same view are rejected.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	for action := range overrides {
+		_, inMain := defaultMainKeys[action]
+		_, inDiff := defaultDiffKeys[action]
+		if !inMain && !inDiff {
+			return keyMap{}, fmt.Errorf("unknown keybinding action: %s", action)
+		}
+	}
+
+	main, err := buildKeyView("main", defaultMainKeys, overrides)
+	if err != nil {
+		return keyMap{}, err
+	}
+	diff, err := buildKeyView("diff", defaultDiffKeys, overrides)
+	if err != nil {
+		return keyMap{}, err
+	}
+	return keyMap{main: main, diff: diff}, nil
+}
+
+func buildKeyView(view string, defaults, overrides map[string][]string) (map[string]string, error) {
+	actions := make([]string, 0, len(defaults))
+	for action := range defaults {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	byKey := make(map[string]string)
+	for _, action := range actions {
+		keys := defaults[action]
+		if override, ok := overrides[action]; ok {
+			keys = override
+		}
+		for _, key := range keys {
+			if existing, ok := byKey[key]; ok && existing != action {
+				return nil, fmt.Errorf("key %q is bound to both %s and %s in the %s view", key, existing, action, view)
+			}
+			byKey[key] = action
+		}
+	}
+	return byKey, nil
+}
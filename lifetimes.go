@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+// fileLifetime tracks when a path first appeared in the loaded history and how
+// often it changed since.
+type fileLifetime struct {
+	path        string
+	firstSeen   time.Time
+	lastChanged time.Time
+	changes     int
+}
+
+// pathChanges lists the paths a commit added, modified and deleted against its
+// first parent. Renames aren't detected, so a moved file starts a new lifetime.
+type pathChanges struct {
+	added, modified, deleted []string
+}
+
+// commitPathChanges diffs the trees of commit and its first parent, or the
+// empty tree for a root commit, keeping the paths inside the -path filter. Only
+// tree objects are read, so it is cheap next to the stats diff.
+func commitPathChanges(filter []string, commit *object.Commit) (*pathChanges, error) {
+	to, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	from := &object.Tree{}
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if from, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, err
+	}
+	var p pathChanges
+	for _, c := range changes {
+		name := c.To.Name
+		if name == "" {
+			name = c.From.Name
+		}
+		if len(filter) > 0 && !pathFilterMatches(filter, name) {
+			continue
+		}
+		action, err := c.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			p.added = append(p.added, name)
+		case merkletrie.Delete:
+			p.deleted = append(p.deleted, name)
+		default:
+			p.modified = append(p.modified, name)
+		}
+	}
+	return &p, nil
+}
+
+// lifetimeTracker replays the path changes of commits[:next] to know which
+// files are present and since when. Playback mostly moves forward, so each
+// step only applies the commits since the last one.
+type lifetimeTracker struct {
+	next     int
+	lastHash string // Hash of commits[next-1], to notice a replaced history
+	lastDate time.Time
+	files    map[string]*fileLifetime
+}
+
+// advance applies commits up to and including index i. It reports false when
+// a commit has no path changes, as with -from-json, report mode or the demo.
+func (t *lifetimeTracker) advance(commits []*commitInfo, i int) bool {
+	if t.files == nil || t.next > i+1 || (t.next > 0 && commits[t.next-1].Hash != t.lastHash) {
+		*t = lifetimeTracker{files: make(map[string]*fileLifetime)}
+	}
+	for ; t.next <= i; t.next++ {
+		c := commits[t.next]
+		if c.Paths == nil {
+			return false
+		}
+		if !hasUnknownDate(c) {
+			t.lastDate = c.Date
+		}
+		for _, path := range c.Paths.deleted {
+			delete(t.files, path)
+		}
+		for _, path := range append(append([]string(nil), c.Paths.added...), c.Paths.modified...) {
+			l := t.files[path]
+			if l == nil {
+				// Modifications of unseen files come from commits filtered out of
+				// the timeline; the file is dated from here.
+				l = &fileLifetime{path: path, firstSeen: t.lastDate}
+				t.files[path] = l
+			}
+			l.lastChanged = t.lastDate
+			l.changes++
+		}
+		t.lastHash = c.Hash
+	}
+	return true
+}
+
+// fileLifetimes returns the files present at the current commit, oldest first,
+// from the path changes the fetcher recorded. ok is false when those aren't
+// available. The result is cached per commit.
+func (m *Model) fileLifetimes() (lifetimes []fileLifetime, ok bool) {
+	current := m.commits[m.currentCommitIndex]
+	if m.lifetimesHash == current.Hash {
+		return m.lifetimes, true
+	}
+	if !m.lifetimeTracker.advance(m.commits, m.currentCommitIndex) {
+		m.lifetimeTracker = lifetimeTracker{}
+		return nil, false
+	}
+
+	lifetimes = make([]fileLifetime, 0, len(m.lifetimeTracker.files))
+	for _, l := range m.lifetimeTracker.files {
+		lifetimes = append(lifetimes, *l)
+	}
+	sort.Slice(lifetimes, func(i, j int) bool {
+		a, b := lifetimes[i], lifetimes[j]
+		if !a.firstSeen.Equal(b.firstSeen) {
+			return a.firstSeen.Before(b.firstSeen)
+		}
+		if a.changes != b.changes {
+			return a.changes < b.changes
+		}
+		return a.path < b.path
+	})
+	m.lifetimesHash = current.Hash
+	m.lifetimes = lifetimes
+	return lifetimes, true
+}
+
+// renderLongestLived lists the oldest files still present at the current commit.
+func (m *Model) renderLongestLived(width, height int) string {
+	lifetimes, ok := m.fileLifetimes()
+	if !ok {
+		return graphAxisStyle.Render(tr("File history unavailable"))
+	}
+	if len(lifetimes) == 0 {
+		return graphAxisStyle.Render(tr("No file history"))
+	}
+	_, now, _ := knownDateBounds(m.commits[:m.currentCommitIndex+1])
+
+	const columns = 30 // age, changes and last changed
+	pathWidth := max(12, width-columns)
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(tr("Oldest files still present")))
+	b.WriteString("\n")
+	b.WriteString(graphAxisStyle.Render(fmt.Sprintf(" %-*s %8s %7s %11s", pathWidth, "", "age", "changes", "last change")))
+	b.WriteString("\n")
+	for i := 0; i < len(lifetimes) && i < height-3; i++ {
+		l := lifetimes[i]
+		b.WriteString(fmt.Sprintf(" %-*s %8s %7d %11s\n", pathWidth, truncatePath(l.path, pathWidth),
+			formatAge(now.Sub(l.firstSeen)), l.changes, l.lastChanged.Format("2006-01-02")))
+	}
+	return b.String()
+}
+
+// formatAge renders a duration in days, months or years.
+func formatAge(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days < 60:
+		return fmt.Sprintf("%dd", days)
+	case days < 730:
+		return fmt.Sprintf("%dmo", days/30)
+	default:
+		return fmt.Sprintf("%.1fy", float64(days)/365)
+	}
+}
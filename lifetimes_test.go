@@ -0,0 +1,41 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFileLifetimesFollowPlayback(t *testing.T) {
+	cfg := fixtureConfig(t, newFixtureRepo(t, fixtureHistory))
+	m := InitialModel(cfg)
+	m.commits = collectCommits(cfg)
+
+	tests := []struct {
+		index int
+		want  []string // Present files, oldest first
+	}{
+		{4, []string{"main.go", "docs/README.md", ".keep", "data.bin", "util.go"}},
+		{0, []string{"README.md", "logo.png", "main.go"}},
+		{2, []string{"logo.png", "main.go", "docs/README.md"}},
+	}
+	for _, tt := range tests {
+		m.currentCommitIndex = tt.index
+		lifetimes, ok := m.fileLifetimes()
+		if !ok {
+			t.Fatalf("commit %d: lifetimes unavailable", tt.index)
+		}
+		var got []string
+		for _, l := range lifetimes {
+			got = append(got, l.path)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("commit %d: files = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+
+	m.currentCommitIndex = 4
+	lifetimes, _ := m.fileLifetimes()
+	if l := lifetimes[0]; l.changes != 2 || !l.firstSeen.Equal(m.commits[0].Date) || !l.lastChanged.Equal(m.commits[1].Date) {
+		t.Errorf("main.go lifetime = %+v, want 2 changes between the first two commits", l)
+	}
+}
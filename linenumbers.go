@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// diffLineNumber is where a diff line sits in the old and new file; 0 on the
+// side it isn't in, and on both for lines outside hunks.
+type diffLineNumber struct {
+	old, new int
+}
+
+// diffLineNumbers follows the @@ hunk headers to number every line of the
+// diff. It also returns the digits needed for the largest number.
+func diffLineNumbers(lines []string) ([]diffLineNumber, int) {
+	numbers := make([]diffLineNumber, len(lines))
+	old, new, largest := 0, 0, 0
+	inHunk := false
+	for i, line := range lines {
+		if match := hunkHeaderPattern.FindStringSubmatch(line); match != nil {
+			old, _ = strconv.Atoi(match[1])
+			new, _ = strconv.Atoi(match[2])
+			inHunk = true
+			continue
+		}
+		if strings.HasPrefix(line, "diff ") || line == "" {
+			inHunk = false
+		}
+		if !inHunk {
+			continue
+		}
+		switch line[0] {
+		case ' ':
+			numbers[i] = diffLineNumber{old: old, new: new}
+			old, new = old+1, new+1
+		case '-':
+			numbers[i] = diffLineNumber{old: old}
+			old++
+		case '+':
+			numbers[i] = diffLineNumber{new: new}
+			new++
+		}
+		largest = max(largest, max(old, new))
+	}
+	return numbers, len(strconv.Itoa(largest))
+}
+
+// indexDiffLines records what the diff view looks up per line once the
+// lines on screen are set: line endings, file headers and line numbers.
+func (m *Model) indexDiffLines() {
+	m.diffLineEndings = scanLineEndings(m.currentDiffLines)
+	m.diffFileStarts = diffFileStarts(m.currentDiffLines)
+	m.diffNumbers, m.diffNumberDigits = diffLineNumbers(m.currentDiffLines)
+	m.diffPairs = replacementPairs(m.currentDiffLines)
+}
+
+// lineNumberGutter is the old and new line number column before diff line i,
+// or "" with line numbers off. A negative i gives a blank gutter.
+func (m *Model) lineNumberGutter(i int) string {
+	if !m.showLineNumbers {
+		return ""
+	}
+	var n diffLineNumber
+	if i >= 0 && i < len(m.diffNumbers) {
+		n = m.diffNumbers[i]
+	}
+	return graphAxisStyle.Render(fmt.Sprintf("%s %s │ ", m.lineNumber(n.old), m.lineNumber(n.new)))
+}
+
+// sideGutter is the single line number column for one side of the
+// side-by-side view.
+func (m *Model) sideGutter(i int, old bool) string {
+	if !m.showLineNumbers {
+		return ""
+	}
+	n := m.diffNumbers[i].new
+	if old {
+		n = m.diffNumbers[i].old
+	}
+	return graphAxisStyle.Render(m.lineNumber(n) + " ")
+}
+
+// lineNumber right-aligns n to the gutter width, blank for 0.
+func (m *Model) lineNumber(n int) string {
+	if n == 0 {
+		return strings.Repeat(" ", m.diffNumberDigits)
+	}
+	return fmt.Sprintf("%*d", m.diffNumberDigits, n)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// locCounter sums the lines in a commit's tree for -loc-every. Blob counts
+// are kept across samples, so each sample only reads the blobs that changed
+// since the ones before it.
+type locCounter struct {
+	mu    sync.Mutex
+	blobs map[plumbing.Hash]int // Lines per blob; 0 for binary files
+}
+
+func newLOCCounter() *locCounter {
+	return &locCounter{blobs: make(map[plumbing.Hash]int)}
+}
+
+// treeLines counts the text lines in every file of tree that passes the path
+// filter. Binary files count as none.
+func (l *locCounter) treeLines(tree *object.Tree, pathFilter []string) (int, error) {
+	total := 0
+	err := tree.Files().ForEach(func(f *object.File) error {
+		if len(pathFilter) > 0 && !pathFilterMatches(pathFilter, f.Name) {
+			return nil
+		}
+		l.mu.Lock()
+		n, ok := l.blobs[f.Hash]
+		l.mu.Unlock()
+		if !ok {
+			text, _, err := fileText(f)
+			if err != nil {
+				return err
+			}
+			n = countLines(text)
+			l.mu.Lock()
+			l.blobs[f.Hash] = n
+			l.mu.Unlock()
+		}
+		total += n
+		return nil
+	})
+	return total, err
+}
+
+// sampleLOC records the size of info's tree. A failure only costs the
+// sample; the commit keeps its place with an interpolated size.
+func (m *Model) sampleLOC(r *git.Repository, info *commitInfo) {
+	commit, err := r.CommitObject(plumbing.NewHash(info.Hash))
+	if err != nil {
+		return
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return
+	}
+	if info.LOC, err = m.loc.treeLines(tree, m.config.PathFilter); err != nil {
+		info.LOC = 0
+		return
+	}
+	info.LOCSampled = true
+}
+
+// placeLOC fills in the size of the commit just appended at index i. Until
+// the next sample arrives it repeats the previous size; a sample then
+// interpolates the commits since the one before it, starting from an empty
+// tree before the first.
+func (m *Model) placeLOC(i int) {
+	c := m.commits[i]
+	if !c.LOCSampled {
+		if i > 0 {
+			c.LOC = m.commits[i-1].LOC
+		}
+		return
+	}
+	m.maxLOC = max(m.maxLOC, c.LOC)
+	prev, base := i-1, 0
+	for prev >= 0 && !m.commits[prev].LOCSampled {
+		prev--
+	}
+	if prev >= 0 {
+		base = m.commits[prev].LOC
+	}
+	for j := prev + 1; j < i; j++ {
+		m.commits[j].LOC = base + (c.LOC-base)*(j-prev)/(i-prev)
+	}
+}
+
+// renderSizeGraph plots the lines of code at each commit in the window as an
+// area rising from the bottom of the canvas, scaled to the largest sample.
+func (m *Model) renderSizeGraph(canvas *BrailleCanvas, startIndex, endIndex int) {
+	scale := max(m.maxLOC, 1)
+	for i := startIndex; i < endIndex; i++ {
+		height := m.commits[i].LOC * (canvas.Height - 1) / scale
+		for y := 0; y <= height; y++ {
+			canvas.Set(i-startIndex, canvas.Height-1-y)
+		}
+	}
+}
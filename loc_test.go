@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestLOCSamplesLastCommit(t *testing.T) {
+	repo := newFixtureRepo(t, fixtureHistory)
+	tests := []struct {
+		name        string
+		limit       int
+		wantSampled []bool
+		wantLastLOC int
+	}{
+		// README.md 4, main.go 6 and util.go 5 lines; .keep is empty and
+		// data.bin binary.
+		{"full history", 0, []bool{true, false, false, true, true}, 15},
+		{"stopped by -limit", 2, []bool{true, true}, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := fixtureConfig(t, repo)
+			cfg.LOCEvery = 3
+			cfg.CommitLimit = tt.limit
+			commits := collectCommits(cfg)
+			if len(commits) != len(tt.wantSampled) {
+				t.Fatalf("got %d commits, want %d", len(commits), len(tt.wantSampled))
+			}
+			for i, c := range commits {
+				if c.LOCSampled != tt.wantSampled[i] {
+					t.Errorf("commit %d sampled = %v, want %v", i, c.LOCSampled, tt.wantSampled[i])
+				}
+			}
+			if last := commits[len(commits)-1]; last.LOC != tt.wantLastLOC {
+				t.Errorf("last commit LOC = %d, want %d", last.LOC, tt.wantLastLOC)
+			}
+		})
+	}
+}
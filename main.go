@@ -5,15 +5,19 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"path"
 	"runtime/pprof"
+	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	yaml "gopkg.in/yaml.v2"
 )
 
-func runNonInteractive(config Config, format string) error {
+// collectCommits runs the fetcher headlessly and returns every commit with cumulative stats filled in.
+func collectCommits(config Config) []*commitInfo {
 	model := InitialModel(config)
 	go model.fetcher()
 
@@ -31,6 +35,26 @@ func runNonInteractive(config Config, format string) error {
 		}
 		allCommits = append(allCommits, commit)
 	}
+	return allCommits
+}
+
+// exportedCommit adds the parent fields, which stats exports leave out by default.
+type exportedCommit struct {
+	commitInfo   `yaml:",inline"`
+	ParentCount  int      `json:"parent_count" yaml:"parent_count"`
+	ParentHashes []string `json:"parent_hashes" yaml:"parent_hashes"`
+}
+
+func runNonInteractive(config Config, format string) error {
+	var allCommits any = collectCommits(config)
+	if config.ExportParents {
+		commits := allCommits.([]*commitInfo)
+		exported := make([]exportedCommit, len(commits))
+		for i, c := range commits {
+			exported[i] = exportedCommit{commitInfo: *c, ParentCount: c.ParentCount, ParentHashes: c.ParentHashes}
+		}
+		allCommits = exported
+	}
 
 	var outputData []byte
 	var err error
@@ -54,32 +78,185 @@ func runNonInteractive(config Config, format string) error {
 
 // Config holds the configurable options for the application
 type Config struct {
-	CommitLimit        int    `yaml:"commitLimit"`
-	RepoPath           string `yaml:"repoPath"`
-	AutoProgress       bool   `yaml:"autoProgress"`
-	ProgressIntervalMs int    `yaml:"progressIntervalMs"`
-	ReportMode         bool   `yaml:"reportMode"`
-	ReportWorkers      int    `yaml:"reportWorkers"`
-	ReportPreload      bool   `yaml:"reportPreload"`
-	ReportPreloadExit  bool   `yaml:"reportPreloadExit"`
-	ReportSamplePct    int    `yaml:"reportSamplePct"`
-	ReportFilePath     string `yaml:"reportFile"`
+	CommitLimit          int    `yaml:"commitLimit"`
+	RepoPath             string `yaml:"repoPath"`
+	AutoProgress         bool   `yaml:"autoProgress"`
+	ProgressIntervalMs   int    `yaml:"progressIntervalMs"`
+	ReportMode           bool   `yaml:"reportMode"`
+	ReportWorkers        int    `yaml:"reportWorkers"`
+	ReportPreload        bool   `yaml:"reportPreload"`
+	ReportPreloadExit    bool   `yaml:"reportPreloadExit"`
+	ReportSamplePct      int    `yaml:"reportSamplePct"`
+	ReportFilePath       string `yaml:"reportFile"`
+	Quiet                bool   `yaml:"quiet"`
+	LogLevel             string `yaml:"logLevel"`
+	LogFile              string `yaml:"logFile"`
+	Demo                 bool   `yaml:"demo"`
+	DemoSize             int    `yaml:"demoSize"`
+	SignedOnly           bool   `yaml:"signedOnly"`
+	UnsignedOnly         bool   `yaml:"unsignedOnly"`
+	ShowGraph            bool   `yaml:"showGraph"`
+	DiffIgnoreWhitespace bool   `yaml:"diffIgnoreWhitespace"`
+	IdleSeconds          int    `yaml:"idleSeconds"`
+	IdleAction           string `yaml:"idleAction"`
+	IdleCycleSeconds     int    `yaml:"idleCycleSeconds"`
+	DedupeCommits        bool   `yaml:"dedupeCommits"`
+	StatsSource          string `yaml:"statsSource"`
+	CommitsFile          string `yaml:"commitsFile"`
+	InitialCommit        string `yaml:"initialCommit"`
+	HighContrast         bool   `yaml:"highContrast"`
+	DeletionsOnTop       bool   `yaml:"deletionsOnTop"`
+	AdditionsShare       int    `yaml:"additionsShare"` // Percent of the changes graph height given to additions
+	Width                int    `yaml:"width"`
+	Height               int    `yaml:"height"`
+	BusyDayThreshold     int    `yaml:"busyDayThreshold"`
+	AnnotationsFile      string `yaml:"annotationsFile"`
+	Tour                 bool   `yaml:"tour"`
+	Source               string `yaml:"source"`
+	ExportParents        bool   `yaml:"exportParents"`
+	ShowIndex            bool   `yaml:"showIndex"`
+	CommitsPerTick       int    `yaml:"commitsPerTick"`
+	Lang                 string `yaml:"lang"`
+	ChurnMode            string `yaml:"churnMode"`
+	IgnoreNoise          bool   `yaml:"ignoreNoise"`
+	GraphMode            string `yaml:"graphMode"`
+	FromJSON             string `yaml:"fromJson"`
+	MessageWrapWidth     int    `yaml:"messageWrapWidth"`
+	HashLength           int    `yaml:"hashLength"`
+	Branch               string `yaml:"branch"`
+	NormalizeAuthors     bool   `yaml:"normalizeAuthors"`
+	IdlePauseSeconds     int    `yaml:"idlePauseSeconds"`
+	RangeFrom            string `yaml:"from"`
+	RangeTo              string `yaml:"to"`
+	CompactWidth         int    `yaml:"compactWidth"` // Terminal width below which panels are shown one at a time
+	DiffNormalizeEOL     bool   `yaml:"diffNormalizeEol"`
+	DebugStats           bool   `yaml:"debugStats"`
+	ShowDiff             string `yaml:"showDiff"`
+	Resume               bool   `yaml:"resume"` // Start at the commit viewed when the last run quit
+	DaySeparators        bool   `yaml:"daySeparators"`
+	Workers              int    `yaml:"fetchWorkers"` // Fetcher goroutines computing commit stats; 0 uses one per CPU
+	NoCache              bool   `yaml:"noCache"`
+	LOCEvery             int    `yaml:"locEvery"`
+	NoAltScreen          bool   `yaml:"noAltScreen"`
+	SyntaxHighlight      bool   `yaml:"syntaxHighlight"`
+	WordDiff             bool   `yaml:"wordDiff"`
+	DiffLineNumbers      bool   `yaml:"diffLineNumbers"`
+	FocusAuthor          string `yaml:"focusAuthor"` // Shown in place of the top-5 contributors once they have commits
+	TagsGlob             string `yaml:"tagsGlob"`    // Tags to mark on the timeline; empty shows none
+	MaxTags              int    `yaml:"maxTags"`     // Newest matching tags kept; 0 keeps all
+
+	PathFilter []string `yaml:"paths"` // Only count changes under these paths
+	ShowSigner bool     `yaml:"showSigner"`
+
+	AlertPaths []string `yaml:"alertPaths"` // Globs for sensitive paths
+
+	SubjectPresets  []string         `yaml:"subjectPresets"`  // Built-in subject rewrites: strip-gitmoji, strip-brackets
+	SubjectRewrites []SubjectRewrite `yaml:"subjectRewrites"` // Regex replacements for timeline subjects
+
+	Highlight HighlightConfig `yaml:"highlight"` // Current timeline row
+
+	Annotations map[string]string `yaml:"annotations"` // commit hash -> note
+
+	Titles map[string]string `yaml:"titles"` // panel id -> title
+
+	Keybindings map[string][]string `yaml:"keybindings"` // action -> keys
 }
 
-func loadConfig() (Config, error) {
-	config := Config{
-		CommitLimit:        -1,
-		RepoPath:           ".",
-		AutoProgress:       true,
-		ProgressIntervalMs: 50, // milliseconds
-		ReportMode:         false,
-		ReportWorkers:      0, // 0 means auto
-		ReportPreload:      false,
-		ReportPreloadExit:  false,
-		ReportSamplePct:    0, // 0 means full run
-		ReportFilePath:     "",
+// HighlightConfig styles the current commit row in the timeline.
+type HighlightConfig struct {
+	Background string `yaml:"background"` // Color for the row background, empty for none
+	Bold       bool   `yaml:"bold"`
+	Marker     string `yaml:"marker"` // Prefix for the current row, e.g. ">" or "▶"
+}
+
+// stringListFlag is a repeatable string flag. Values given on the command line
+// replace the ones from the config file rather than appending to them.
+type stringListFlag struct {
+	values []string
+	set    bool
+}
+
+func (f *stringListFlag) String() string { return strings.Join(f.values, ",") }
+
+func (f *stringListFlag) Set(value string) error {
+	if !f.set {
+		f.values = nil
+		f.set = true
 	}
+	f.values = append(f.values, value)
+	return nil
+}
 
+// signatureFilterAllows reports whether a commit with the given signature status
+// passes the -signed-only / -unsigned-only filters.
+func (c Config) signatureFilterAllows(signed bool) bool {
+	if c.SignedOnly {
+		return signed
+	}
+	if c.UnsignedOnly {
+		return !signed
+	}
+	return true
+}
+
+// defaultConfig returns the configuration used when .visagit.yml and the
+// flags leave an option unset.
+func defaultConfig() Config {
+	return Config{
+		CommitLimit:          -1,
+		RepoPath:             ".",
+		AutoProgress:         true,
+		ProgressIntervalMs:   50, // milliseconds
+		ReportMode:           false,
+		ReportWorkers:        0, // 0 means auto
+		ReportPreload:        false,
+		ReportPreloadExit:    false,
+		ReportSamplePct:      0, // 0 means full run
+		ReportFilePath:       "",
+		Quiet:                false,
+		LogLevel:             "warn",
+		LogFile:              "",
+		Demo:                 false,
+		DemoSize:             500,
+		SignedOnly:           false,
+		UnsignedOnly:         false,
+		ShowGraph:            false,
+		DiffIgnoreWhitespace: false,
+		IdleSeconds:          0, // 0 disables the idle action
+		IdleAction:           idleActionReplay,
+		Lang:                 "en",
+		ChurnMode:            churnSum,
+		GraphMode:            graphModeLog,
+		HashLength:           7,
+		NormalizeAuthors:     false,
+		IdlePauseSeconds:     60,
+		CompactWidth:         80,
+		DiffNormalizeEOL:     true,
+		SyntaxHighlight:      false,
+		MaxTags:              1000,
+		WordDiff:             true,
+		IdleCycleSeconds:     10,
+		DedupeCommits:        false,
+		StatsSource:          statsSourceGoGit,
+		CommitsFile:          "",
+		InitialCommit:        initialCommitInclude,
+		Highlight:            HighlightConfig{Background: "236"},
+		HighContrast:         false,
+		DeletionsOnTop:       false,
+		AdditionsShare:       50,
+		Width:                0, // 0 follows the terminal
+		Height:               0,
+		BusyDayThreshold:     20, // 0 disables the busy days list
+		AnnotationsFile:      "",
+		Tour:                 false,
+		Source:               sourceHistory,
+		AlertPaths:           nil,
+	}
+}
+
+// loadConfig reads .visagit.yml from the working directory over the defaults.
+func loadConfig() (Config, error) {
+	config := defaultConfig()
 	configFile, err := os.ReadFile(".visagit.yml")
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -93,6 +270,13 @@ func loadConfig() (Config, error) {
 		return config, fmt.Errorf("failed to unmarshal config file: %v", err)
 	}
 
+	if _, err := newKeyMap(config.Keybindings); err != nil {
+		return config, fmt.Errorf("invalid keybindings: %v", err)
+	}
+	if err := validatePanelTitles(config.Titles); err != nil {
+		return config, fmt.Errorf("invalid titles: %v", err)
+	}
+
 	return config, nil
 }
 
@@ -110,12 +294,93 @@ func main() {
 	progressIntervalFlag := flag.Int("interval", config.ProgressIntervalMs, "Interval for automatic progression in milliseconds")
 	profile := flag.Bool("profile", false, "profile cpu")
 	outputFlag := flag.String("output", "", "Output format for non-interactive mode (json or yaml)")
+	verifyFlag := flag.Bool("verify", false, "Cross-check computed stats against git numstat and report discrepancies")
+	exportFramesFlag := flag.String("export-frames", "", "Write the dashboard after each commit as numbered .txt files with colors to this directory and exit (replay with clear; cat)")
+	recordFlag := flag.String("record", "", "Record the replay as an asciicast v2 file at this path (play with asciinema)")
+	exportCSVFlag := flag.String("export-csv", "", "Write one CSV row per commit (hash,author,date,files,additions,deletions,churn and cumulative totals) to this path and exit")
+	exportJSONFlag := flag.String("export-json", "", "Write per-author, monthly, weekday and hourly developer stats as JSON to this path and exit")
+	exportHotspotsFlag := flag.String("export-hotspots", "", "Write per-file hotspot CSV (file,commits,additions,deletions,churn) over the full history to this path and exit")
 	reportFlag := flag.Bool("report", config.ReportMode, "Load all data first, then show a final report view")
 	reportWorkersFlag := flag.Int("workers", config.ReportWorkers, "Workers for report mode (0 = auto, >0 = exact)")
 	reportPreloadFlag := flag.Bool("report-preload", config.ReportPreload, "Preload report data before starting the TUI")
 	reportPreloadExitFlag := flag.Bool("report-preload-exit", config.ReportPreloadExit, "Exit after preloading the report (skip TUI)")
 	reportSamplePctFlag := flag.Int("report-sample", config.ReportSamplePct, "Report sample percent (0 = full, 1-100)")
 	reportFileFlag := flag.String("report-file", config.ReportFilePath, "Report file path for resume/save")
+	quietFlag := flag.Bool("quiet", config.Quiet, "Suppress informational messages in headless modes")
+	logLevelFlag := flag.String("log-level", config.LogLevel, "Log level (debug, info, warn, error)")
+	logFileFlag := flag.String("log-file", config.LogFile, "Write logs to this file (required to see logs in the TUI)")
+	demoFlag := flag.Bool("demo", config.Demo, "Replay synthetic commits instead of reading a repository")
+	demoSizeFlag := flag.Int("demo-size", config.DemoSize, "Number of synthetic commits generated in demo mode")
+	signedOnlyFlag := flag.Bool("signed-only", config.SignedOnly, "Only show commits that carry a signature")
+	unsignedOnlyFlag := flag.Bool("unsigned-only", config.UnsignedOnly, "Only show commits without a signature")
+	showGraphFlag := flag.Bool("graph", config.ShowGraph, "Draw branch/merge lanes in the timeline (toggle with t)")
+	statsSourceFlag := flag.String("stats-source", config.StatsSource, "Authoritative source for per-commit stats when go-git and git disagree: go-git or git")
+	commitsFileFlag := flag.String("commits-file", config.CommitsFile, "Visualize only the commits listed in this file (one hash per line), in file order")
+	initialCommitFlag := flag.String("initial-commit", config.InitialCommit, "How root commits affect the changes graph: include, exclude (from scaling) or hide")
+	highContrastFlag := flag.Bool("high-contrast", config.HighContrast, "Use a high-contrast palette with thick borders")
+	additionsShareFlag := flag.Int("additions-share", config.AdditionsShare, "Percent of the changes graph height given to additions, moving the zero line (cycle 50/70/30 with z)")
+	deletionsOnTopFlag := flag.Bool("deletions-on-top", config.DeletionsOnTop, "Draw deletions above the zero line and additions below (toggle with f)")
+	widthFlag := flag.Int("width", config.Width, "Render at this terminal width instead of the actual one (0 follows the terminal)")
+	heightFlag := flag.Int("height", config.Height, "Render at this terminal height instead of the actual one (0 follows the terminal)")
+	busyDayFlag := flag.Int("busy-day-threshold", config.BusyDayThreshold, "Flag days where one author makes more than this many commits (0 disables)")
+	annotationsFlag := flag.String("annotations", config.AnnotationsFile, "YAML/JSON file mapping commit hashes to notes shown when the commit is current")
+	tourFlag := flag.Bool("tour", config.Tour, "Pause playback on each annotated commit; press any key to continue")
+	lineNumbersFlag := flag.Bool("line-numbers", config.DiffLineNumbers, "Show old and new line numbers in the diff view (toggle with #)")
+	focusAuthorFlag := flag.String("focus-author", config.FocusAuthor, "Show this author's stats and cadence in place of the top-5 contributors")
+	wordDiffFlag := flag.Bool("word-diff", config.WordDiff, "Pick out the changed words when a removed line is followed by its replacement in the diff view")
+	tagsGlobFlag := flag.String("tags-glob", config.TagsGlob, "Mark tags matching this pattern (e.g. 'v*', '*' for all) on the timeline, loaded in the background")
+	maxTagsFlag := flag.Int("max-tags", config.MaxTags, "Keep only this many of the newest matching tags (0 keeps all)")
+	syntaxHighlightFlag := flag.Bool("syntax-highlight", config.SyntaxHighlight, "Color keywords, strings, numbers and comments in the diff view by file type")
+	noAltScreenFlag := flag.Bool("no-alt-screen", config.NoAltScreen, "Draw in the normal screen instead of the alternate screen buffer, for terminals that handle it badly")
+	locEveryFlag := flag.Int("loc-every", config.LOCEvery, "Count lines of code in every Nth commit's tree and interpolate between, for the size graph mode (0 = off)")
+	noCacheFlag := flag.Bool("no-cache", config.NoCache, "Don't read or write the commit stats cache ("+statsCacheFile+")")
+	daySeparatorsFlag := flag.Bool("day-separators", config.DaySeparators, "Show a date row before each day's first commit in the timeline (toggle with D)")
+	workersFlag := flag.Int("fetch-workers", config.Workers, "Goroutines computing commit stats while loading outside report mode (0 uses one per CPU)")
+	showDiffFlag := flag.String("show-diff", config.ShowDiff, "Start in the diff view of this commit (any git revision)")
+	resumeFlag := flag.Bool("resume", config.Resume, "Start paused at the commit viewed when the last run on this repository quit")
+	debugStatsFlag := flag.Bool("debug-stats", config.DebugStats, "Show render time, tick rate, commit count and memory usage in a corner")
+	normalizeEOLFlag := flag.Bool("diff-normalize-eol", config.DiffNormalizeEOL, "Hide CRLF line endings and byte order marks in the diff view (toggle with r)")
+	compactWidthFlag := flag.Int("compact-width", config.CompactWidth, "Show one panel at a time, switched with tab, when the terminal is narrower than this (0 disables)")
+	pathFilterFlag := &stringListFlag{values: config.PathFilter}
+	flag.Var(pathFilterFlag, "path", "Only count changes under this path, relative to the repository root (repeatable)")
+	fromFlag := flag.String("from", config.RangeFrom, "Only show commits after this ref (from..to)")
+	toFlag := flag.String("to", config.RangeTo, "Only show commits up to this ref instead of HEAD or -branch")
+	idlePauseFlag := flag.Int("idle-pause", config.IdlePauseSeconds, "Stop redrawing after this many seconds paused without input, until the next key (0 disables)")
+	normalizeAuthorsFlag := flag.Bool("normalize-authors", config.NormalizeAuthors, "Count author names differing only in case or spacing as one contributor")
+	branchFlag := flag.String("branch", config.Branch, "Branch or ref to visualize instead of HEAD")
+	showSignerFlag := flag.Bool("show-signer", config.ShowSigner, "Show the signing key and signer above the diff (verifies with git)")
+	hashLengthFlag := flag.Int("hash-length", config.HashLength, "Characters of each hash shown in the timeline (H toggles full hashes)")
+	messageWrapFlag := flag.Int("message-wrap-width", config.MessageWrapWidth, "Wrap the commit message above the diff at this width (0 uses the view width)")
+	fromJSONFlag := flag.String("from-json", config.FromJSON, "Load commits from a file written by -export-json or -output json instead of reading the history")
+	graphModeFlag := flag.String("graph-mode", config.GraphMode, "Changes graph mode: log (bar height by size), proportional (split by additions/deletions ratio), cadence (churn with commits per week) or size (lines of code, needs -loc-every)")
+	ignoreNoiseFlag := flag.Bool("ignore-noise", config.IgnoreNoise, "Leave whitespace-only changes and pure renames out of the graph, counting them separately (runs git per commit)")
+	churnModeFlag := flag.String("churn-mode", config.ChurnMode, "How commit churn is computed: sum (additions+deletions), max (larger of the two), net (|additions-deletions|) or additions")
+	langFlag := flag.String("lang", config.Lang, "Language of the UI strings (en, sv)")
+	commitsPerTickFlag := flag.Int("commits-per-tick", config.CommitsPerTick, "Advance playback this many loaded commits per tick instead of following loading (0 follows loading)")
+	showIndexFlag := flag.Bool("show-index", config.ShowIndex, "Number the timeline rows and show the current/total commit in its header")
+	exportParentsFlag := flag.Bool("export-parents", config.ExportParents, "Include parent count and hashes in -output exports")
+	sourceFlag := flag.String("source", config.Source, "Where commits come from: history, reflog or stash")
+	dedupeFlag := flag.Bool("dedupe", config.DedupeCommits, "Detect cherry-picked duplicates via git patch-id and count their changes once (slow on large repos)")
+	ignoreWhitespaceFlag := flag.Bool("ignore-whitespace", config.DiffIgnoreWhitespace, "Ignore whitespace changes in the diff view (toggle with i)")
+	idleSecondsFlag := flag.Int("idle", config.IdleSeconds, "Seconds without input before the idle action starts (0 = off)")
+	idleActionFlag := flag.String("idle-action", config.IdleAction, "Idle action: replay or cycle-years")
+	alertPathsFlag := &stringListFlag{values: config.AlertPaths}
+	flag.Var(alertPathsFlag, "alert-path", "Flag commits touching paths matching this glob (repeatable, supports **)")
+	subjectPresetFlag := &stringListFlag{values: config.SubjectPresets}
+	flag.Var(subjectPresetFlag, "subject-preset", "Tidy timeline subjects with a built-in rewrite: strip-gitmoji or strip-brackets (repeatable)")
+	subjectStripFlag := &stringListFlag{}
+	flag.Var(subjectStripFlag, "subject-strip", "Remove matches of this regex from timeline subjects, after any configured subjectRewrites (repeatable)")
+
+	if len(os.Args) > 1 && os.Args[1] == "completion" {
+		if len(os.Args) != 3 {
+			log.Fatalf("usage: %s completion [bash|zsh|fish]", os.Args[0])
+		}
+		if err := writeCompletion(os.Stdout, os.Args[2], os.Args[0], flag.CommandLine); err != nil {
+			log.Fatalf("Error generating completion: %v", err)
+		}
+		return
+	}
+
 	flag.Parse()
 
 	if *profile {
@@ -138,25 +403,221 @@ func main() {
 	config.ReportPreloadExit = *reportPreloadExitFlag
 	config.ReportSamplePct = *reportSamplePctFlag
 	config.ReportFilePath = *reportFileFlag
+	config.Quiet = *quietFlag
+	config.LogLevel = *logLevelFlag
+	config.LogFile = *logFileFlag
+	config.Demo = *demoFlag
+	config.DemoSize = *demoSizeFlag
+	config.SignedOnly = *signedOnlyFlag
+	config.UnsignedOnly = *unsignedOnlyFlag
+	config.ShowGraph = *showGraphFlag
+	config.DiffIgnoreWhitespace = *ignoreWhitespaceFlag
+	config.DedupeCommits = *dedupeFlag
+	config.Source = *sourceFlag
+	config.ExportParents = *exportParentsFlag
+	config.ShowIndex = *showIndexFlag
+	config.CommitsPerTick = *commitsPerTickFlag
+	config.Lang = *langFlag
+	config.ChurnMode = *churnModeFlag
+	config.IgnoreNoise = *ignoreNoiseFlag
+	config.GraphMode = *graphModeFlag
+	config.FromJSON = *fromJSONFlag
+	config.MessageWrapWidth = *messageWrapFlag
+	config.HashLength = *hashLengthFlag
+	config.ShowSigner = *showSignerFlag
+	config.Branch = *branchFlag
+	config.NormalizeAuthors = *normalizeAuthorsFlag
+	config.IdlePauseSeconds = *idlePauseFlag
+	config.RangeFrom = *fromFlag
+	config.RangeTo = *toFlag
+	config.PathFilter = pathFilterFlag.values
+	config.CompactWidth = *compactWidthFlag
+	config.DiffNormalizeEOL = *normalizeEOLFlag
+	config.DebugStats = *debugStatsFlag
+	config.ShowDiff = *showDiffFlag
+	config.Resume = *resumeFlag
+	config.Workers = *workersFlag
+	config.DaySeparators = *daySeparatorsFlag
+	config.NoCache = *noCacheFlag
+	config.LOCEvery = *locEveryFlag
+	config.NoAltScreen = *noAltScreenFlag
+	config.SyntaxHighlight = *syntaxHighlightFlag
+	config.TagsGlob = *tagsGlobFlag
+	config.WordDiff = *wordDiffFlag
+	config.FocusAuthor = *focusAuthorFlag
+	config.DiffLineNumbers = *lineNumbersFlag
+	config.MaxTags = *maxTagsFlag
+	if config.HashLength < 4 {
+		log.Fatalf("-hash-length must be at least 4")
+	}
+	if config.FromJSON != "" && config.ReportMode {
+		log.Fatalf("-from-json and -report are mutually exclusive")
+	}
+	if indexOf(graphModes, config.GraphMode) < 0 {
+		log.Fatalf("unsupported graph mode: %s. supported modes are: %s", config.GraphMode, strings.Join(graphModes, ", "))
+	}
+	if config.GraphMode == graphModeSize && config.LOCEvery <= 0 {
+		log.Fatalf("-graph-mode %s needs -loc-every", graphModeSize)
+	}
+	if config.LOCEvery > 0 && (config.ReportMode || config.FromJSON != "") {
+		log.Fatalf("-loc-every counts lines while reading the history, so it can't be combined with -report or -from-json")
+	}
+	if _, err := path.Match(config.TagsGlob, ""); err != nil {
+		log.Fatalf("invalid -tags-glob %q: %v", config.TagsGlob, err)
+	}
+	switch config.ChurnMode {
+	case churnSum, churnMax, churnNet, churnAdditions:
+	default:
+		log.Fatalf("unsupported churn mode: %s. supported modes are: %s, %s, %s, %s", config.ChurnMode, churnSum, churnMax, churnNet, churnAdditions)
+	}
+	if err := setLanguage(config.Lang); err != nil {
+		log.Fatalf("%v", err)
+	}
+	config.Tour = *tourFlag
+	config.AnnotationsFile = *annotationsFlag
+	if config.AnnotationsFile != "" {
+		notes, err := loadAnnotations(config.AnnotationsFile)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		if config.Annotations == nil {
+			config.Annotations = make(map[string]string)
+		}
+		for hash, note := range notes {
+			config.Annotations[hash] = note
+		}
+	}
+	config.BusyDayThreshold = *busyDayFlag
+	config.Width = *widthFlag
+	config.Height = *heightFlag
+	config.DeletionsOnTop = *deletionsOnTopFlag
+	config.AdditionsShare = *additionsShareFlag
+	config.HighContrast = *highContrastFlag
+	config.InitialCommit = *initialCommitFlag
+	config.CommitsFile = *commitsFileFlag
+	config.StatsSource = *statsSourceFlag
+	config.IdleSeconds = *idleSecondsFlag
+	config.IdleAction = *idleActionFlag
+	if config.IdleAction != idleActionReplay && config.IdleAction != idleActionCycleYears {
+		log.Fatalf("unsupported idle action: %s. supported actions are: %s, %s", config.IdleAction, idleActionReplay, idleActionCycleYears)
+	}
+	if config.StatsSource != statsSourceGoGit && config.StatsSource != statsSourceGit {
+		log.Fatalf("unsupported stats source: %s. supported sources are: %s, %s", config.StatsSource, statsSourceGoGit, statsSourceGit)
+	}
+	if config.InitialCommit != initialCommitInclude && config.InitialCommit != initialCommitExclude && config.InitialCommit != initialCommitHide {
+		log.Fatalf("unsupported initial commit mode: %s. supported modes are: %s, %s, %s", config.InitialCommit, initialCommitInclude, initialCommitExclude, initialCommitHide)
+	}
+	if config.AdditionsShare < 10 || config.AdditionsShare > 90 {
+		log.Fatalf("-additions-share must be between 10 and 90, got %d", config.AdditionsShare)
+	}
+	if config.HighContrast {
+		applyHighContrast()
+		// A dark background band is easy to miss; mark the row instead unless
+		// the highlight was configured explicitly.
+		if config.Highlight == (HighlightConfig{Background: "236"}) {
+			config.Highlight = HighlightConfig{Bold: true, Marker: ">"}
+		}
+	}
+	if config.Source != sourceHistory && config.Source != sourceReflog && config.Source != sourceStash {
+		log.Fatalf("unsupported source: %s. supported sources are: %s, %s, %s", config.Source, sourceHistory, sourceReflog, sourceStash)
+	}
+	if config.Source != sourceHistory && config.CommitsFile != "" {
+		log.Fatalf("-source %s and -commits-file are mutually exclusive", config.Source)
+	}
+	config.AlertPaths = alertPathsFlag.values
+	config.SubjectPresets = subjectPresetFlag.values
+	for _, pattern := range subjectStripFlag.values {
+		config.SubjectRewrites = append(config.SubjectRewrites, SubjectRewrite{Pattern: pattern})
+	}
+	if _, err := compileSubjectRewrites(config.SubjectPresets, config.SubjectRewrites); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if config.SignedOnly && config.UnsignedOnly {
+		log.Fatalf("-signed-only and -unsigned-only are mutually exclusive")
+	}
 
 	// If a positional argument is provided, it overrides repoPathFlag
 	if flag.NArg() > 0 {
 		config.RepoPath = flag.Arg(0)
 	}
 
+	interactive := *outputFlag == "" && !*verifyFlag && *exportHotspotsFlag == "" && *exportJSONFlag == "" && *exportCSVFlag == "" && *exportFramesFlag == "" && !(config.ReportMode && config.ReportPreload && config.ReportPreloadExit)
+	// Checked before logging is set up, which discards log output in the TUI.
+	if interactive && !isTerminal(os.Stdout) {
+		log.Fatalf("stdout is not a terminal; run headless with -output json|yaml, -export-json, -export-csv, -export-frames or -verify")
+	}
+	closeLog, err := setupLogging(config, interactive)
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer closeLog()
+	// log.Fatalf skips deferred calls and, with logging set up, writes through
+	// slog, so later failures go to the log file, close it and then exit.
+	fatalf := func(format string, args ...any) {
+		if config.LogFile != "" {
+			slog.Error(fmt.Sprintf(format, args...))
+		}
+		closeLog()
+		log.SetOutput(os.Stderr)
+		log.Fatalf(format, args...)
+	}
+
+	if *exportHotspotsFlag != "" {
+		if err := exportHotspots(config, *exportHotspotsFlag); err != nil {
+			fatalf("Error exporting hotspots: %v", err)
+		}
+		return
+	}
+
+	if *exportCSVFlag != "" {
+		if err := exportCommitsCSV(config, *exportCSVFlag); err != nil {
+			fatalf("Error exporting commits: %v", err)
+		}
+		return
+	}
+
+	if *exportFramesFlag != "" {
+		if err := exportFrames(config, *exportFramesFlag); err != nil {
+			fatalf("Error exporting frames: %v", err)
+		}
+		return
+	}
+
+	if *exportJSONFlag != "" {
+		if err := exportDeveloperStats(config, *exportJSONFlag); err != nil {
+			fatalf("Error exporting developer stats: %v", err)
+		}
+		return
+	}
+
+	if *verifyFlag {
+		ok, err := runVerify(config, os.Stdout)
+		if err != nil {
+			fatalf("Error in verify mode: %v", err)
+		}
+		if !ok {
+			closeLog()
+			os.Exit(1)
+		}
+		return
+	}
+
 	if *outputFlag != "" {
 		if err := runNonInteractive(config, *outputFlag); err != nil {
-			log.Fatalf("Error in non-interactive mode: %v", err)
+			fatalf("Error in non-interactive mode: %v", err)
 		}
 		return
 	}
 
 	if config.ReportMode && config.ReportPreload {
 		start := time.Now()
+		var eta etaEstimator
 		progress := func(processed, total, workers int, engine string) {
 			if total > 0 {
+				now := time.Now()
+				eta.observe(now, processed)
 				percent := (float64(processed) / float64(total)) * 100
-				fmt.Printf("\rPreloading report... %d/%d (%.1f%%) using %d workers (%s)", processed, total, percent, workers, engine)
+				fmt.Printf("\rPreloading report... %d/%d (%.1f%%) using %d workers (%s)%s\033[K", processed, total, percent, workers, engine, eta.label(now, total))
 			} else {
 				fmt.Printf("\rPreloading report... using %d workers (%s)", workers, engine)
 			}
@@ -166,13 +627,17 @@ func main() {
 		progressGitPar := func(processed, total, workers int) {
 			progress(processed, total, workers, engine)
 		}
-		repo, commits, maxAdditions, maxDeletions, total, workers, err := loadAllCommitsGitParallel(config, progressGitPar)
+		if config.Quiet {
+			progressGitPar = nil
+		}
+		repo, commits, total, workers, err := loadAllCommitsGitParallel(config, progressGitPar)
 		if err != nil {
-			log.Printf("Error preloading report: %v", err)
-			return
+			fatalf("Error preloading report: %v", err)
+		}
+		if !config.Quiet {
+			elapsed := time.Since(start).Round(100 * time.Millisecond)
+			fmt.Printf("\nPreload complete in %s using %s\n", elapsed, engine)
 		}
-		elapsed := time.Since(start).Round(100 * time.Millisecond)
-		fmt.Printf("\nPreload complete in %s using %s\n", elapsed, engine)
 
 		if config.ReportPreloadExit {
 			return
@@ -181,8 +646,7 @@ func main() {
 		model := InitialModel(config)
 		model.repo = repo
 		model.commits = commits
-		model.maxAdditions = maxAdditions
-		model.maxDeletions = maxDeletions
+		model.recomputeMaxima()
 		model.loadingComplete = true
 		model.autoProgress = false
 		model.reportTotal = total
@@ -197,7 +661,7 @@ func main() {
 		p := tea.NewProgram(m)
 		m.SetProgram(p)
 		if _, err := p.Run(); err != nil {
-			log.Printf("Error running program: %v", err)
+			fatalf("Error running program: %v", err)
 		}
 		return
 	}
@@ -205,13 +669,27 @@ func main() {
 	// Create a new Bubble Tea model
 	model := InitialModel(config)
 	m := &model
+	if *recordFlag != "" {
+		if m.recorder, err = newRecorder(*recordFlag); err != nil {
+			fatalf("%v", err)
+		}
+	}
 
 	// Interactive mode with full terminal UI
 	p := tea.NewProgram(m)
 	m.SetProgram(p) // Pass the program reference to the model
 
 	// Run the program
-	if _, err := p.Run(); err != nil {
-		log.Fatalf("Error running program: %v", err)
+	_, err = p.Run()
+	m.recorder.finish()
+	if err != nil {
+		fatalf("Error running program: %v", err)
 	}
 }
+
+// isTerminal reports whether f is a character device, i.e. a terminal the
+// TUI can draw on rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+)
+
+// gitSignalStats counts the lines a commit changes once whitespace-only edits
+// are ignored and renames are detected, so a pure move counts as nothing.
+// pathspec limits it to the paths the totals were counted over.
+func gitSignalStats(repoPath, hash string, pathspec []string) (additions, deletions int, err error) {
+	args := []string{"-C", repoPath, "show", "--numstat", "-w", "-M", "--no-color", "--pretty=format:", "--root", hash}
+	cmd := exec.Command("git", append(args, pathspec...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("git show failed: %v", err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		add, del := 0, 0
+		if fields[0] != "-" {
+			fmt.Sscanf(fields[0], "%d", &add)
+		}
+		if fields[1] != "-" {
+			fmt.Sscanf(fields[1], "%d", &del)
+		}
+		additions += add
+		deletions += del
+	}
+	return additions, deletions, nil
+}
+
+// markNoise records how much of c's change is whitespace-only or a pure rename.
+// The graph leaves that part out; the totals still include it.
+func markNoise(cfg Config, c *commitInfo) {
+	if !cfg.IgnoreNoise || c.DuplicateOf != "" {
+		return
+	}
+	add, del, err := gitSignalStats(cfg.RepoPath, c.Hash, cfg.pathspec())
+	if err != nil {
+		slog.Warn("failed to read whitespace-insensitive stats", "commit", c.Hash, "err", err)
+		return
+	}
+	c.NoiseAdditions = max(0, c.Additions-add)
+	c.NoiseDeletions = max(0, c.Deletions-del)
+}
+
+// graphStats returns the additions and deletions the graph plots for c.
+func graphStats(c *commitInfo) (additions, deletions int) {
+	return c.Additions - c.NoiseAdditions, c.Deletions - c.NoiseDeletions
+}
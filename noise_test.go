@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestMarkNoiseFollowsPathFilter(t *testing.T) {
+	repo := newFixtureRepo(t, []fixtureCommit{
+		{
+			author:  "Alice",
+			date:    "2024-01-01T10:00:00Z",
+			message: "Initial import",
+			write: map[string]string{
+				"main.go": "package main\n\nfunc main() {}\n",
+				"util.go": "package main\n\nfunc add(a, b int) int { return a + b }\n",
+			},
+		},
+		{
+			author:  "Bob",
+			date:    "2024-01-02T11:30:00Z",
+			message: "Reindent util and grow main",
+			write: map[string]string{
+				"main.go": "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+				"util.go": "package main\n\nfunc add(a, b int) int {  return a + b }\n",
+			},
+		},
+	})
+	cfg := fixtureConfig(t, repo)
+	cfg.IgnoreNoise = true
+	cfg.PathFilter = []string{"util.go"}
+
+	head, err := exec.Command("git", "-C", repo, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The -path filtered totals only cover util.go's whitespace change.
+	c := &commitInfo{Hash: strings.TrimSpace(string(head)), Additions: 1, Deletions: 1}
+	markNoise(cfg, c)
+	if c.NoiseAdditions != 1 || c.NoiseDeletions != 1 {
+		t.Errorf("noise = %d/%d, want 1/1", c.NoiseAdditions, c.NoiseDeletions)
+	}
+}
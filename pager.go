@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// pagerFinishedMsg is sent once the external pager exits and the TUI resumes.
+type pagerFinishedMsg struct {
+	err error
+}
+
+// pagerCommand builds the command for $PAGER, falling back to less. PAGER may
+// carry arguments, e.g. "less -R".
+func pagerCommand(file string) *exec.Cmd {
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		args = []string{"less", "-R"}
+	}
+	return exec.Command(args[0], append(args[1:], file)...)
+}
+
+// openPager suspends the TUI and shows the current diff in an external pager.
+func (m *Model) openPager() tea.Cmd {
+	f, err := os.CreateTemp("", "visarepo-*.diff")
+	if err != nil {
+		m.diffNotice = fmt.Sprintf("pager failed: %v", err)
+		return nil
+	}
+	path := f.Name()
+	_, err = f.WriteString(m.currentDiff)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		m.diffNotice = fmt.Sprintf("pager failed: %v", err)
+		return nil
+	}
+	return tea.ExecProcess(pagerCommand(path), func(err error) tea.Msg {
+		os.Remove(path)
+		return pagerFinishedMsg{err: err}
+	})
+}
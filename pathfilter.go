@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+// pathspec returns the rev-list arguments limiting history to -path, or nil
+// when no filter is set.
+func (c Config) pathspec() []string {
+	if len(c.PathFilter) == 0 {
+		return nil
+	}
+	return append([]string{"--"}, c.PathFilter...)
+}
+
+// pathFilterMatches reports whether name is one of the filter paths or lies
+// under one of them, the way git treats a plain directory pathspec.
+func pathFilterMatches(filter []string, name string) bool {
+	for _, p := range filter {
+		p = strings.TrimSuffix(path.Clean(p), "/")
+		if p == "." || name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// filterFileStats keeps the stats of files inside the filter paths. Stats are
+// returned unchanged when no filter is set.
+func filterFileStats(filter []string, stats []fileStat) []fileStat {
+	if len(filter) == 0 {
+		return stats
+	}
+	var kept []fileStat
+	for _, s := range stats {
+		if pathFilterMatches(filter, s.Name) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// filterCommitStats recomputes numstat totals over the filter paths only.
+func filterCommitStats(filter []string, stat commitStats) commitStats {
+	if len(filter) == 0 {
+		return stat
+	}
+	fileStats := filterFileStats(filter, stat.fileStats)
+	filtered := sumFileStats(fileStats)
+	filtered.fileStats = fileStats
+	filtered.dirChurn = dirChurn(fileStats)
+	for _, p := range stat.paths {
+		if pathFilterMatches(filter, p) {
+			filtered.paths = append(filtered.paths, p)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Bounds for the playback speed keys.
+const (
+	minProgressInterval = time.Millisecond
+	maxProgressInterval = 2 * time.Second
+)
+
+// appendCommit adds a commit received from the fetcher, filling in its
+// cumulative stats and updating the graph scale.
+func (m *Model) appendCommit(c *commitInfo) {
+	c.DiffLoaded = true
+
+	if len(m.commits) > 0 {
+		lastCommit := m.commits[len(m.commits)-1]
+		c.CumulativeFiles = lastCommit.CumulativeFiles + c.Files
+		c.CumulativeAdditions = lastCommit.CumulativeAdditions + c.Additions
+		c.CumulativeDeletions = lastCommit.CumulativeDeletions + c.Deletions
+	} else {
+		c.CumulativeFiles = c.Files
+		c.CumulativeAdditions = c.Additions
+		c.CumulativeDeletions = c.Deletions
+	}
+
+	if m.scalesCommit(c) {
+		additions, deletions := graphStats(c)
+		m.maxAdditions = max(m.maxAdditions, additions)
+		m.maxDeletions = max(m.maxDeletions, deletions)
+	}
+
+	m.commits = append(m.commits, c)
+	if m.config.LOCEvery > 0 {
+		m.placeLOC(len(m.commits) - 1)
+	}
+}
+
+// playbackBehind reports whether the current commit is behind the newest
+// loaded one, i.e. the playback position trails what the fetcher has loaded.
+func (m *Model) playbackBehind() bool {
+	return m.currentCommitIndex < len(m.commits)-1
+}
+
+// advancePlayback moves the current commit forward by up to n loaded commits,
+// stopping early on a tour stop.
+func (m *Model) advancePlayback(n int) {
+	for step := 0; step < n && m.currentCommitIndex < len(m.commits)-1; step++ {
+		m.currentCommitIndex++
+		if m.tourStop(m.commits[m.currentCommitIndex]) {
+			m.autoProgress = false
+			m.tourPaused = true
+			return
+		}
+	}
+}
+
+// catchUp jumps to the newest commit the fetcher has produced, taking in any
+// that are still buffered because playback was paused. It returns how many
+// commits were skipped over.
+func (m *Model) catchUp() int {
+	for n := len(m.processedCommitsChan); n > 0; n-- {
+		c, ok := <-m.processedCommitsChan
+		if !ok {
+			m.loadingComplete = true
+			break
+		}
+		m.appendCommit(c)
+	}
+	if len(m.commits) == 0 {
+		return 0
+	}
+	skipped := len(m.commits) - 1 - m.currentCommitIndex
+	m.currentCommitIndex = len(m.commits) - 1
+	return max(skipped, 0)
+}
+
+// changeSpeed halves the tick interval when faster, doubles it otherwise.
+func (m *Model) changeSpeed(faster bool) {
+	if faster {
+		m.progressInterval /= 2
+	} else {
+		m.progressInterval *= 2
+	}
+	if m.progressInterval < minProgressInterval {
+		m.progressInterval = minProgressInterval
+	}
+	if m.progressInterval > maxProgressInterval {
+		m.progressInterval = maxProgressInterval
+	}
+}
+
+// speedLabel describes the playback speed relative to the configured interval.
+func (m *Model) speedLabel() string {
+	base := time.Duration(m.config.ProgressIntervalMs) * time.Millisecond
+	if base <= 0 || m.progressInterval <= 0 {
+		return "1x"
+	}
+	return fmt.Sprintf("%.3gx", float64(base)/float64(m.progressInterval))
+}
+
+// seek jumps to a loaded commit and stops auto-progress so playback doesn't
+// drift away from it.
+func (m *Model) seek(index int) {
+	if len(m.commits) == 0 {
+		return
+	}
+	m.autoProgress = false
+	m.currentCommitIndex = max(0, min(index, len(m.commits)-1))
+}
+
+// seekDecile jumps to the start of the given tenth (0-9) of the loaded commits.
+func (m *Model) seekDecile(decile int) {
+	m.seek(len(m.commits) * decile / 10)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// etaSmoothing weights the newest rate sample; lower values steady the estimate
+// against bursts from uneven commit sizes.
+const etaSmoothing = 0.2
+
+// etaEstimator turns processed/total samples into a smoothed time remaining.
+type etaEstimator struct {
+	lastAt   time.Time
+	lastDone int
+	rate     float64 // commits per second, exponentially smoothed
+}
+
+// observe records that done items were processed by now.
+func (e *etaEstimator) observe(now time.Time, done int) {
+	if e.lastAt.IsZero() || done < e.lastDone {
+		e.lastAt, e.lastDone, e.rate = now, done, 0
+		return
+	}
+	elapsed := now.Sub(e.lastAt).Seconds()
+	if elapsed <= 0 || done == e.lastDone {
+		return
+	}
+	sample := float64(done-e.lastDone) / elapsed
+	if e.rate == 0 {
+		e.rate = sample
+	} else {
+		e.rate = etaSmoothing*sample + (1-etaSmoothing)*e.rate
+	}
+	e.lastAt, e.lastDone = now, done
+}
+
+// remaining estimates the time left at now. The estimate counts down between
+// samples so the indicator keeps moving while a slow batch is in flight.
+func (e *etaEstimator) remaining(now time.Time, total int) (time.Duration, bool) {
+	if e.rate <= 0 || total <= 0 {
+		return 0, false
+	}
+	left := time.Duration(float64(total-e.lastDone) / e.rate * float64(time.Second))
+	left -= now.Sub(e.lastAt)
+	if left < 0 {
+		left = 0
+	}
+	return left, true
+}
+
+// label renders the estimate for a loading line, or "" before the first rate sample.
+func (e *etaEstimator) label(now time.Time, total int) string {
+	left, ok := e.remaining(now, total)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" ETA %s", formatETA(left))
+}
+
+func formatETA(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// recorder writes the replay to an asciicast v2 file for -record: a JSON
+// header line, then one output event per frame that redraws the screen.
+type recorder struct {
+	f     *os.File
+	w     *bufio.Writer
+	start time.Time
+	last  string // Previous frame, so unchanged ticks add nothing
+	err   error  // First write error; recording stops there
+}
+
+type castHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+func newRecorder(path string) (*recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %v", err)
+	}
+	return &recorder{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// frame appends content shown at now. The header is written with the first
+// frame, once the terminal size is known.
+func (r *recorder) frame(now time.Time, content string, width, height int) {
+	if r == nil || r.f == nil || r.err != nil || content == r.last {
+		return
+	}
+	if r.start.IsZero() {
+		header, _ := json.Marshal(castHeader{Version: 2, Width: width, Height: height, Timestamp: now.Unix()})
+		r.write(string(header))
+		r.start = now
+	}
+	r.last = content
+	// The player writes events to a terminal verbatim, so lines need
+	// carriage returns and each frame starts from a cleared screen.
+	data := "\x1b[H\x1b[2J" + strings.ReplaceAll(content, "\n", "\r\n")
+	event, _ := json.Marshal([]any{now.Sub(r.start).Seconds(), "o", data})
+	r.write(string(event))
+}
+
+func (r *recorder) write(line string) {
+	if _, err := r.w.WriteString(line + "\n"); err != nil {
+		r.err = err
+		slog.Warn("recording stopped", "path", r.f.Name(), "err", err)
+	}
+}
+
+// finish flushes and closes the recording. It is safe to call more than once.
+func (r *recorder) finish() {
+	if r == nil || r.f == nil {
+		return
+	}
+	err := r.w.Flush()
+	if cerr := r.f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil && r.err == nil {
+		slog.Warn("failed to finish recording", "path", r.f.Name(), "err", err)
+	}
+	slog.Info("saved recording", "path", r.f.Name())
+	r.f = nil
+}
+
+// recordFrame captures a frame View rendered for -record, and closes the
+// recording once the whole history has been played.
+func (m *Model) recordFrame(content string) {
+	if m.recorder == nil || m.recorder.f == nil || m.width <= 0 {
+		return
+	}
+	// resize keeps a margin; the recording gets the whole terminal.
+	m.recorder.frame(m.nowFunc(), content, m.width+viewMargin, m.height+viewMargin)
+	if m.loadingComplete && !m.playbackBehind() {
+		m.recorder.finish()
+	}
+}
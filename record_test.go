@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordWritesRenderedFrames(t *testing.T) {
+	m, _ := clockedModel(t, func(c *Config) {
+		c.Width, c.Height = 100, 30
+	})
+	m.resize(100, 30)
+	path := filepath.Join(t.TempDir(), "replay.cast")
+	rec, err := newRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.recorder = rec
+	m.currentCommitIndex = len(m.commits) - 1
+
+	first := m.View().Content
+	if m.recorder.f != nil {
+		t.Fatal("recording still open after the last commit was shown")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []string
+	for s := bufio.NewScanner(f); s.Scan(); {
+		lines = append(lines, s.Text())
+	}
+	if len(lines) != 2 {
+		t.Fatalf("recording has %d lines, want a header and one frame", len(lines))
+	}
+	var header castHeader
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Width != 100 || header.Height != 30 {
+		t.Errorf("recorded size %dx%d, want the terminal's 100x30", header.Width, header.Height)
+	}
+	var event []any
+	if err := json.Unmarshal([]byte(lines[1]), &event); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b[H\x1b[2J" + strings.ReplaceAll(first, "\n", "\r\n")
+	if len(event) != 3 || event[2] != want {
+		t.Error("recorded frame differs from the one View rendered")
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit sources, selected with the source config.
+const (
+	sourceHistory = "history" // Commits reachable from HEAD
+	sourceReflog  = "reflog"  // HEAD reflog entries, oldest first
+	sourceStash   = "stash"   // Stash entries, oldest first
+)
+
+// reflogEntry is one movement recorded in a reflog.
+type reflogEntry struct {
+	hash    string
+	date    time.Time // When the ref moved, not when the commit was authored
+	subject string    // e.g. "checkout: moving from main to topic"
+}
+
+// readReflog lists the entries of the reflog for the given source, oldest first.
+// The same commit may appear several times.
+func readReflog(repoPath, source string) ([]reflogEntry, error) {
+	ref := "HEAD"
+	if source == sourceStash {
+		ref = "refs/stash"
+		if exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", ref).Run() != nil {
+			return nil, nil // No stashes
+		}
+	}
+	cmd := exec.Command("git", "-C", repoPath, "reflog", "show", "--date=unix", "--format=%H%x1f%gd%x1f%gs", ref, "--")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git reflog failed: %v", err)
+	}
+
+	var entries []reflogEntry
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "\x1f", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		e := reflogEntry{hash: parts[0], subject: parts[2]}
+		// %gd renders as HEAD@{<unix time>} with --date=unix.
+		if open, end := strings.Index(parts[1], "@{"), strings.LastIndex(parts[1], "}"); open >= 0 && end > open {
+			if secs, err := strconv.ParseInt(parts[1][open+2:end], 10, 64); err == nil {
+				e.date = time.Unix(secs, 0)
+			}
+		}
+		entries = append(entries, e)
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// apply replaces the commit's date and subject with the reflog entry's, so the
+// timeline shows when and how the ref moved.
+func (e reflogEntry) apply(c *commitInfo) {
+	if !e.date.IsZero() {
+		c.Date = e.date
+	}
+	if e.subject != "" && e.subject != strings.SplitN(c.Message, "\n", 2)[0] {
+		c.Message = e.subject + "\n\n" + c.Message
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenModel plays the fixture history into a model with a fixed size, clock
+// and time zone, stopped on the fourth commit.
+func goldenModel(t *testing.T) *Model {
+	t.Helper()
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+	cfg := fixtureConfig(t, newFixtureRepo(t, fixtureHistory))
+	cfg.Width, cfg.Height = 120, 50
+	m := InitialModel(cfg)
+	m.nowFunc = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	for _, c := range collectCommits(cfg) {
+		m.appendCommit(c)
+	}
+	m.loadingComplete = true
+	m.currentCommitIndex = 3
+	return &m
+}
+
+// assertGolden compares got, without ANSI sequences, with
+// testdata/golden/<name>.golden, or rewrites that file with -update.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	got = ansi.Strip(got)
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from %s:\n--- got\n%s\n--- want\n%s", name, path, got, want)
+	}
+}
+
+func TestRenderGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func(m *Model) string
+	}{
+		{"timeline", func(m *Model) string { return m.renderTimeline(12) }},
+		{"developer_stats", func(m *Model) string { return m.renderDeveloperStats() }},
+		{"braille_graph", func(m *Model) string { return m.renderBrailleGraph(10) }},
+		{"braille_graph_deletions_on_top", func(m *Model) string {
+			m.deletionsOnTop = true
+			return m.renderBrailleGraph(10)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := tt.render(goldenModel(t))
+			if strings.TrimSpace(ansi.Strip(out)) == "" {
+				t.Fatal("rendered nothing")
+			}
+			assertGolden(t, tt.name, out)
+		})
+	}
+}
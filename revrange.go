@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// revision is the tip whose history is visualized: -to, else -branch, else HEAD.
+func (c Config) revision() string {
+	if c.RangeTo != "" {
+		return c.RangeTo
+	}
+	if c.Branch != "" {
+		return c.Branch
+	}
+	return "HEAD"
+}
+
+// revisionRange is the rev-list argument selecting the visualized commits.
+// With -from it excludes that commit and its ancestors.
+func (c Config) revisionRange() string {
+	if c.RangeFrom != "" {
+		return c.RangeFrom + ".." + c.revision()
+	}
+	return c.revision()
+}
+
+// resolveRange fails fast on a ref that doesn't exist, which rev-list would
+// otherwise turn into an empty history.
+func resolveRange(r *git.Repository, c Config) error {
+	refs := []string{c.revision()}
+	if c.RangeFrom != "" {
+		refs = append(refs, c.RangeFrom)
+	}
+	for _, ref := range refs {
+		if _, err := r.ResolveRevision(plumbing.Revision(ref)); err != nil {
+			return fmt.Errorf("ref not found: %s", ref)
+		}
+	}
+	return nil
+}
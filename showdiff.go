@@ -0,0 +1,45 @@
+package main
+
+import "fmt"
+
+// resolveShowDiff resolves the -show-diff revision to the commit whose diff
+// opens once it loads. An unknown revision leaves the dashboard up with a
+// notice instead.
+func (m *Model) resolveShowDiff() {
+	hashes, _, err := resolveCommits(m.config.RepoPath, []string{m.config.ShowDiff})
+	if err != nil || len(hashes) == 0 {
+		m.notice = "show-diff: no commit " + m.config.ShowDiff
+		return
+	}
+	m.showDiffHash = hashes[0]
+	m.resumeHash = ""
+}
+
+// showDiffAt opens the diff view on c if it is the -show-diff commit. It
+// reports whether it did.
+func (m *Model) showDiffAt(c *commitInfo, index int) bool {
+	if m.showDiffHash == "" || c.Hash != m.showDiffHash {
+		return false
+	}
+	m.showDiffHash = ""
+	m.currentCommitIndex = index
+	m.autoProgress = false
+	if m.config.FromJSON != "" && m.repo == nil {
+		m.notice = "Diffs unavailable"
+		return true
+	}
+	m.diffState = inDiffView
+	m.diffScroll = 0
+	m.loadCurrentDiff()
+	return true
+}
+
+// missedShowDiff reports a -show-diff commit that loading finished without,
+// e.g. because filters left it out.
+func (m *Model) missedShowDiff() {
+	if m.showDiffHash == "" {
+		return
+	}
+	m.notice = fmt.Sprintf("show-diff: %s not loaded", m.config.ShowDiff)
+	m.showDiffHash = ""
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// signatureInfo is what git reports about a commit signature.
+type signatureInfo struct {
+	status string // git's %G? code
+	keyID  string
+	signer string
+	err    error
+}
+
+// signatureStatuses describes git's %G? codes.
+var signatureStatuses = map[string]string{
+	"G": "good",
+	"B": "bad",
+	"U": "good, unknown validity",
+	"X": "good, expired",
+	"Y": "good, expired key",
+	"R": "good, revoked key",
+	"E": "cannot be checked",
+	"N": "unsigned",
+}
+
+// gitSignature asks git to verify a commit signature. Verification shells out
+// to gpg or ssh-keygen, so callers cache the result.
+func gitSignature(repoPath, hash string) signatureInfo {
+	out, err := exec.Command("git", "-C", repoPath, "log", "-1", "--format=%G?%x1f%GK%x1f%GS", hash).Output()
+	if err != nil {
+		return signatureInfo{err: fmt.Errorf("git log failed: %v", err)}
+	}
+	parts := strings.SplitN(strings.TrimRight(string(out), "\n"), "\x1f", 3)
+	if len(parts) < 3 {
+		return signatureInfo{err: fmt.Errorf("unexpected git log output: %q", out)}
+	}
+	return signatureInfo{status: parts[0], keyID: parts[1], signer: parts[2]}
+}
+
+// signatureLine summarizes the signature of c for the diff view header.
+func (m *Model) signatureLine(c *commitInfo) string {
+	if !c.Signed {
+		return "Signature: unsigned"
+	}
+	info, ok := m.signatureCache[c.Hash]
+	if !ok {
+		info = gitSignature(m.config.RepoPath, c.Hash)
+		m.signatureCache[c.Hash] = info
+	}
+	if info.err != nil {
+		return fmt.Sprintf("Signature: present, not verified (%v)", info.err)
+	}
+	line := "Signature: " + signatureStatuses[info.status]
+	if info.keyID != "" {
+		line += ", key " + info.keyID
+	}
+	if info.signer != "" {
+		line += ", signed by " + info.signer
+	}
+	return line
+}
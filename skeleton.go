@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// renderSkeleton draws the dashboard frame with placeholder rows while the
+// first commits load, so the layout doesn't jump when data arrives.
+func (m *Model) renderSkeleton() string {
+	statsPanelHeight := 8
+	changesPanelHeight, timelinePanelHeight := m.panelHeights(statsPanelHeight)
+	width := m.columnWidth() - 2
+	placeholder := func(rows int) string {
+		line := graphAxisStyle.Render(strings.Repeat("─", max(0, width-8)))
+		return strings.TrimSuffix(strings.Repeat(" "+line+"\n", max(0, rows)), "\n")
+	}
+
+	stats := "  " + tr("Loading commits...") + "\n\n" + placeholder(statsPanelHeight-5)
+	if m.compact {
+		return m.renderPanelWithHeader(m.panelTitle(panelStats), stats, width, m.height)
+	}
+	leftColumn := lipgloss.JoinVertical(lipgloss.Left,
+		m.renderPanelWithHeader(m.panelTitle(panelStats), stats, width, statsPanelHeight),
+		m.renderPanelWithHeader(m.panelTitle(panelChanges), placeholder(changesPanelHeight-3), width, changesPanelHeight),
+		m.renderPanelWithHeader(m.panelTitle(panelTimeline), placeholder(timelinePanelHeight-3), width, timelinePanelHeight),
+	)
+	rightColumn := m.renderPanelWithHeader(m.panelTitle(panelDevelopers), placeholder(m.height-3), width, m.height)
+	return lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, rightColumn)
+}
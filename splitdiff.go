@@ -0,0 +1,144 @@
+package main
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// splitRow is one row of the side-by-side diff. Inside hunks it pairs a
+// removed or context line on the left with an added or context line on the
+// right; everything else (message, file headers, @@ lines) spans both sides.
+type splitRow struct {
+	src      int  // Index in currentDiffLines of the row's first line
+	whole    bool // Shown across both columns, from line src
+	old, new int  // Indices of the left and right lines, -1 for none
+}
+
+// splitRows pairs the lines of a unified diff. A run of removals followed by
+// a run of additions is matched up line by line, so changed lines sit side by
+// side and the longer run leaves blanks on the other side.
+func splitRows(lines []string) []splitRow {
+	var rows []splitRow
+	inHunk := false
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case strings.HasPrefix(line, "diff "):
+			inHunk = false
+		}
+		if !inHunk || line == "" || strings.HasPrefix(line, "@@") || (line[0] != ' ' && line[0] != '-' && line[0] != '+') {
+			rows = append(rows, splitRow{src: i, whole: true, old: -1, new: -1})
+			i++
+			continue
+		}
+		if line[0] == ' ' {
+			rows = append(rows, splitRow{src: i, old: i, new: i})
+			i++
+			continue
+		}
+		start := i
+		var dels, adds []int
+		for ; i < len(lines) && strings.HasPrefix(lines[i], "-"); i++ {
+			dels = append(dels, i)
+		}
+		for ; i < len(lines) && strings.HasPrefix(lines[i], "+"); i++ {
+			adds = append(adds, i)
+		}
+		for j := 0; j < max(len(dels), len(adds)); j++ {
+			row := splitRow{src: start + j, old: -1, new: -1}
+			if j < len(dels) {
+				row.old = dels[j]
+			}
+			if j < len(adds) {
+				row.new = adds[j]
+				if j >= len(dels) {
+					row.src = adds[j]
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// splitRowAt returns the row showing diff line i.
+func splitRowAt(rows []splitRow, i int) int {
+	r := 0
+	for r+1 < len(rows) && rows[r+1].src <= i {
+		r++
+	}
+	return r
+}
+
+// scrollDiff moves the diff view by delta lines, or by delta rows when the
+// diff is shown side by side or wrapped.
+func (m *Model) scrollDiff(delta int) {
+	if !m.splitDiff && m.diffWrap {
+		m.scrollWrapped(delta)
+		return
+	}
+	if !m.splitDiff {
+		m.diffScroll = max(m.diffScroll+delta, 0)
+		return
+	}
+	rows := splitRows(m.currentDiffLines)
+	if len(rows) == 0 {
+		return
+	}
+	r := max(0, min(splitRowAt(rows, m.diffScroll)+delta, len(rows)-1))
+	m.diffScroll = rows[r].src
+}
+
+// renderSplitDiff draws the diff with old lines on the left and new lines on
+// the right, from the row holding diffScroll.
+func (m *Model) renderSplitDiff(height int) string {
+	rows := splitRows(m.currentDiffLines)
+	if len(rows) == 0 {
+		return ""
+	}
+	colWidth := max((m.width+viewMargin-3)/2, minWrapWidth)
+	start := splitRowAt(rows, m.diffScroll)
+	end := min(start+height, len(rows))
+
+	var lang *syntaxLanguage
+	if m.config.SyntaxHighlight {
+		lang = m.diffLanguageAt(rows[start].src)
+	}
+	pairs := m.wordDiffPairs()
+	separator := graphAxisStyle.Render(" │ ")
+	var b strings.Builder
+	for _, row := range rows[start:end] {
+		if row.whole {
+			line := m.currentDiffLines[row.src]
+			if m.config.SyntaxHighlight {
+				if l, ok := diffFileLanguage(line); ok {
+					lang = l
+				}
+			}
+			b.WriteString(m.renderDiffLine(row.src, lang, -1, pairs))
+			if eol, ok := m.diffLineEndings[row.src]; ok {
+				b.WriteString(graphAxisStyle.Render("  " + eol.label()))
+			}
+			b.WriteString("\n")
+			continue
+		}
+		left := m.renderSplitSide(row.old, true, lang, colWidth, pairs)
+		right := m.renderSplitSide(row.new, false, lang, colWidth, pairs)
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, separator, right) + "\n")
+	}
+	return b.String()
+}
+
+// renderSplitSide renders diff line i, after the old or new line number,
+// clipped and padded to width, or blank padding when the side has no line.
+func (m *Model) renderSplitSide(i int, old bool, lang *syntaxLanguage, width int, pairs map[int]int) string {
+	if i < 0 {
+		return strings.Repeat(" ", width)
+	}
+	gutter := m.sideGutter(i, old)
+	rendered := gutter + m.renderDiffLine(i, lang, max(width-lipgloss.Width(gutter), 1), pairs)
+	return rendered + strings.Repeat(" ", max(width-lipgloss.Width(rendered), 0))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// stateFile holds UI choices that carry over between runs. It sits next to
+// .visagit.yml, which stays hand-edited.
+const stateFile = ".visagit.state"
+
+type persistedState struct {
+	FullHashes bool              `json:"fullHashes"`
+	Positions  map[string]string `json:"positions,omitempty"` // Last viewed commit by absolute repo path
+}
+
+// loadState reads the saved state. A missing or unreadable file yields the
+// defaults.
+func loadState() persistedState {
+	var s persistedState
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("failed to read state file", "path", stateFile, "err", err)
+		}
+		return s
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		slog.Warn("failed to parse state file", "path", stateFile, "err", err)
+	}
+	return s
+}
+
+func saveState(s persistedState) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err == nil {
+		err = os.WriteFile(stateFile, data, 0o644)
+	}
+	if err != nil {
+		slog.Warn("failed to save state file", "path", stateFile, "err", err)
+	}
+}
+
+// positionKey identifies a repository in persistedState.Positions. Demo data
+// has no position worth keeping.
+func positionKey(cfg Config) string {
+	if cfg.Demo {
+		return ""
+	}
+	if abs, err := filepath.Abs(cfg.RepoPath); err == nil {
+		return abs
+	}
+	return cfg.RepoPath
+}
+
+// savePosition remembers the current commit so the next run can resume there
+// with -resume.
+func (m *Model) savePosition() {
+	key := positionKey(m.config)
+	if key == "" || len(m.commits) == 0 {
+		return
+	}
+	if m.state.Positions == nil {
+		m.state.Positions = make(map[string]string)
+	}
+	m.state.Positions[key] = m.commits[m.currentCommitIndex].Hash
+	saveState(m.state)
+}
+
+// resumeAt moves to the saved position if c is that commit, pausing playback
+// there. It reports whether it did.
+func (m *Model) resumeAt(c *commitInfo, index int) bool {
+	if m.resumeHash == "" || c.Hash != m.resumeHash {
+		return false
+	}
+	m.resumeHash = ""
+	m.currentCommitIndex = index
+	m.autoProgress = false
+	m.notice = "Resumed at " + m.shortHash(c.Hash) + " (g: start)"
+	return true
+}
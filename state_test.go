@@ -0,0 +1,29 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResumeNeedsFlag(t *testing.T) {
+	t.Chdir(t.TempDir())
+	repo, err := filepath.Abs("repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := "0123456789012345678901234567890123456789"
+	saveState(persistedState{Positions: map[string]string{repo: saved}})
+
+	for _, resume := range []bool{false, true} {
+		cfg := fixtureConfig(t, repo)
+		cfg.Resume = resume
+		m := InitialModel(cfg)
+		want := ""
+		if resume {
+			want = saved
+		}
+		if m.resumeHash != want {
+			t.Errorf("-resume=%v: resuming at %q, want %q", resume, m.resumeHash, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+)
+
+// statsCacheFile keeps the stats the fetcher computed so replays of the same
+// history skip the diffs. Commit hashes never change meaning, so entries are
+// never stale; only missing ones are computed.
+const statsCacheFile = ".visagit.cache"
+
+// statsCacheVersion is bumped whenever the meaning of a cached field changes.
+const statsCacheVersion = 2
+
+type cachedStats struct {
+	Hash      string         `json:"hash"`
+	Source    string         `json:"source"` // StatsSource the stats were computed with
+	Files     int            `json:"files"`
+	Additions int            `json:"additions"`
+	Deletions int            `json:"deletions"`
+	Churn     int            `json:"churn"`
+	DirChurn  map[string]int `json:"dir_churn,omitempty"`
+	FileStats []fileStat     `json:"file_stats,omitempty"`
+
+	// Paths, for file lifetimes
+	Added    []string `json:"added,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+	Deleted  []string `json:"deleted,omitempty"`
+}
+
+// statsCacheKey tells apart the stats of a commit from each StatsSource, as
+// go-git and git numstat count some changes differently.
+type statsCacheKey struct {
+	source, hash string
+}
+
+type statsCacheFileData struct {
+	Version int           `json:"version"`
+	Commits []cachedStats `json:"commits"`
+}
+
+// statsCache is read by the fetch workers while the emitter collects new
+// entries in fresh, so entries is never written after loading. A nil cache is
+// valid and never hits.
+type statsCache struct {
+	source  string
+	entries map[statsCacheKey]cachedStats
+	fresh   []cachedStats
+}
+
+// statsCacheUsable reports whether cached stats match what cfg would compute.
+// The cached totals are unfiltered, so path filters and alert paths rule it
+// out.
+func (c Config) statsCacheUsable() bool {
+	return !c.NoCache && !c.Demo && c.ChurnMode == churnSum && len(c.PathFilter) == 0 && len(c.AlertPaths) == 0
+}
+
+// loadStatsCache reads the cache file for stats computed with source. A
+// missing, unreadable or outdated file yields an empty cache.
+func loadStatsCache(source string) *statsCache {
+	c := &statsCache{source: source, entries: make(map[statsCacheKey]cachedStats)}
+	data, err := os.ReadFile(statsCacheFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("failed to read stats cache", "path", statsCacheFile, "err", err)
+		}
+		return c
+	}
+	var file statsCacheFileData
+	if err := json.Unmarshal(data, &file); err != nil {
+		slog.Warn("failed to parse stats cache", "path", statsCacheFile, "err", err)
+		return c
+	}
+	if file.Version != statsCacheVersion {
+		slog.Info("ignoring stats cache from another version", "path", statsCacheFile, "version", file.Version)
+		return c
+	}
+	for _, s := range file.Commits {
+		c.entries[statsCacheKey{s.Source, s.Hash}] = s
+	}
+	slog.Debug("loaded stats cache", "path", statsCacheFile, "commits", len(c.entries))
+	return c
+}
+
+func (c *statsCache) lookup(hash string) (cachedStats, bool) {
+	if c == nil {
+		return cachedStats{}, false
+	}
+	s, ok := c.entries[statsCacheKey{c.source, hash}]
+	return s, ok
+}
+
+// apply sets the cached stats and paths on info.
+func (s cachedStats) apply(info *commitInfo) {
+	info.Files, info.Additions, info.Deletions, info.Churn = s.Files, s.Additions, s.Deletions, s.Churn
+	info.DirChurn = s.DirChurn
+	info.FileStats, info.FileStatsLoaded = s.FileStats, true
+	info.Paths = &pathChanges{added: s.Added, modified: s.Modified, deleted: s.Deleted}
+}
+
+// add queues the stats of a freshly processed commit for the next save.
+func (c *statsCache) add(info *commitInfo) {
+	if c == nil || !info.FileStatsLoaded || info.Paths == nil {
+		return
+	}
+	if _, ok := c.lookup(info.Hash); ok {
+		return
+	}
+	c.fresh = append(c.fresh, cachedStats{
+		Hash:      info.Hash,
+		Source:    c.source,
+		Files:     info.Files,
+		Additions: info.Additions,
+		Deletions: info.Deletions,
+		Churn:     info.Churn,
+		DirChurn:  info.DirChurn,
+		FileStats: info.FileStats,
+		Added:     info.Paths.added,
+		Modified:  info.Paths.modified,
+		Deleted:   info.Paths.deleted,
+	})
+}
+
+// save writes the loaded and the fresh entries back if the fetch added
+// anything. entries itself is left alone, so it is safe to call while the
+// workers might still look entries up.
+func (c *statsCache) save() {
+	if c == nil || len(c.fresh) == 0 {
+		return
+	}
+	file := statsCacheFileData{Version: statsCacheVersion, Commits: make([]cachedStats, 0, len(c.entries)+len(c.fresh))}
+	for _, s := range c.entries {
+		file.Commits = append(file.Commits, s)
+	}
+	file.Commits = append(file.Commits, c.fresh...)
+	data, err := json.Marshal(file)
+	if err == nil {
+		err = os.WriteFile(statsCacheFile, data, 0o644)
+	}
+	if err != nil {
+		slog.Warn("failed to save stats cache", "path", statsCacheFile, "err", err)
+		return
+	}
+	slog.Debug("saved stats cache", "path", statsCacheFile, "commits", len(file.Commits))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// cachedConfig is fixtureConfig with the stats cache on, kept in a temporary
+// working directory.
+func cachedConfig(t *testing.T, repo string) Config {
+	t.Helper()
+	cfg := fixtureConfig(t, repo)
+	t.Chdir(t.TempDir())
+	cfg.NoCache = false
+	return cfg
+}
+
+func TestStatsCacheHitsKeepFileStats(t *testing.T) {
+	cfg := cachedConfig(t, newFixtureRepo(t, fixtureHistory))
+	want := collectCommits(cfg)
+	if _, err := os.Stat(statsCacheFile); err != nil {
+		t.Fatalf("no cache written: %v", err)
+	}
+	cache := loadStatsCache(cfg.StatsSource)
+	if len(cache.entries) != len(want) {
+		t.Fatalf("cache has %d commits, want %d", len(cache.entries), len(want))
+	}
+
+	got := collectCommits(cfg)
+	for i, c := range got {
+		w := want[i]
+		if !c.FileStatsLoaded || !reflect.DeepEqual(c.FileStats, w.FileStats) {
+			t.Errorf("%s: cached file stats %v (loaded %v), want %v", w.Message, c.FileStats, c.FileStatsLoaded, w.FileStats)
+		}
+		if !reflect.DeepEqual(c.Paths, w.Paths) {
+			t.Errorf("%s: cached paths %+v, want %+v", w.Message, c.Paths, w.Paths)
+		}
+		if c.Additions != w.Additions || c.Deletions != w.Deletions || c.Files != w.Files {
+			t.Errorf("%s: cached totals %d/%d/%d, want %d/%d/%d", w.Message, c.Files, c.Additions, c.Deletions, w.Files, w.Additions, w.Deletions)
+		}
+	}
+}
+
+func TestStatsCacheKeyedBySource(t *testing.T) {
+	cfg := cachedConfig(t, newFixtureRepo(t, textHistory))
+	commits := collectCommits(cfg)
+
+	if _, ok := loadStatsCache(statsSourceGit).lookup(commits[0].Hash); ok {
+		t.Error("go-git stats served to the git stats source")
+	}
+	cfg.StatsSource = statsSourceGit
+	collectCommits(cfg)
+	for _, source := range []string{statsSourceGoGit, statsSourceGit} {
+		if _, ok := loadStatsCache(source).lookup(commits[0].Hash); !ok {
+			t.Errorf("no %s entry after fetching with both sources", source)
+		}
+	}
+}
+
+// TestStatsCacheSaveAfterEarlyStop stops the fetch while workers are still
+// busy and saves the cache, for go test -race.
+func TestStatsCacheSaveAfterEarlyStop(t *testing.T) {
+	var history []fixtureCommit
+	for i := range 40 {
+		history = append(history, fixtureCommit{
+			author:  "Alice",
+			date:    fmt.Sprintf("2024-02-%02dT10:00:00Z", 1+i%28),
+			message: fmt.Sprintf("Commit %d", i),
+			write:   map[string]string{"n.txt": strings.Repeat("line\n", i+1)},
+		})
+	}
+	cfg := cachedConfig(t, newFixtureRepo(t, history))
+	cfg.Workers = 4
+	cfg.CommitLimit = 3
+	collectCommits(cfg)
+	if n := len(loadStatsCache(cfg.StatsSource).entries); n != 3 {
+		t.Errorf("cache has %d commits, want the 3 emitted", n)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Stats sources, selected with the statsSource config. go-git and git numstat
+// disagree on some changes (submodule pointers, mode-only changes); go-git
+// commits touching those are cross-checked and any difference is logged.
+const (
+	statsSourceGoGit = "go-git" // In-process patch stats (default for the live fetcher)
+	statsSourceGit   = "git"    // `git show --numstat`, as used by report mode and -verify
+)
+
+// gitFileStats reads the per-file stats of one commit from git numstat, with the
+// same options runGitNumstat uses for whole histories.
+func gitFileStats(repoPath, hash string) ([]fileStat, error) {
+	cmd := exec.Command("git", "-C", repoPath, "show", "--numstat", "--no-renames", "--no-color", "--pretty=format:", "--root", hash)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show failed: %v", err)
+	}
+
+	var stats []fileStat
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		s := fileStat{Name: fields[2]}
+		if fields[0] != "-" {
+			fmt.Sscanf(fields[0], "%d", &s.Additions)
+		}
+		if fields[1] != "-" {
+			fmt.Sscanf(fields[1], "%d", &s.Deletions)
+		}
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
+
+// treeFileStats returns the same per-file line counts as go-git's
+// Patch.Stats without building the patch: added and deleted files are counted
+// straight from their contents and only modified files go through a line diff,
+// whose chunks are tallied and dropped. It also reports whether the changes
+// touch entries git numstat counts differently, so only those commits pay for
+// a cross-check.
+func treeFileStats(from, to *object.Tree) (stats []fileStat, mayDiverge bool, err error) {
+	changes, err := from.Diff(to)
+	if err != nil {
+		return nil, false, err
+	}
+	var none object.ChangeEntry
+	for _, c := range changes {
+		fromMode, toMode := c.From.TreeEntry.Mode, c.To.TreeEntry.Mode
+		hasFrom, hasTo := c.From != none, c.To != none
+		additions, deletions, changed, err := changeLineStats(c)
+		if err != nil {
+			return nil, false, err
+		}
+		if (hasFrom && fromMode == filemode.Submodule) || (hasTo && toMode == filemode.Submodule) {
+			mayDiverge = true
+		}
+		if hasFrom && hasTo && fromMode != toMode && !changed {
+			mayDiverge = true
+		}
+		// Like Patch.Stats, skip binary files and submodule pointer updates.
+		if !changed {
+			continue
+		}
+		name := c.From.Name
+		switch {
+		case !hasFrom:
+			name = c.To.Name
+		case hasTo && c.From.Name != c.To.Name:
+			name = c.From.Name + " => " + c.To.Name
+		}
+		stats = append(stats, fileStat{Name: name, Additions: additions, Deletions: deletions})
+	}
+	return stats, mayDiverge, nil
+}
+
+// changeLineStats counts the lines added and deleted by one change. changed is
+// false where go-git's patch would have no chunks: binary or non-file entries
+// and empty files.
+func changeLineStats(c *object.Change) (additions, deletions int, changed bool, err error) {
+	from, to, err := c.Files()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	fromText, fromBinary, err := fileText(from)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	toText, toBinary, err := fileText(to)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	switch {
+	case fromBinary || toBinary:
+		return 0, 0, false, nil
+	case fromText == toText:
+		return 0, 0, fromText != "", nil
+	case fromText == "":
+		return countLines(toText), 0, true, nil
+	case toText == "":
+		return 0, countLines(fromText), true, nil
+	}
+	for _, d := range diff.Do(fromText, toText) {
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			additions += countLines(d.Text)
+		case diffmatchpatch.DiffDelete:
+			deletions += countLines(d.Text)
+		}
+	}
+	return additions, deletions, true, nil
+}
+
+func fileText(f *object.File) (text string, binary bool, err error) {
+	if f == nil {
+		return "", false, nil
+	}
+	if binary, err = f.IsBinary(); err != nil || binary {
+		return "", binary, err
+	}
+	text, err = f.Contents()
+	return text, false, err
+}
+
+// countLines counts lines the way go-git's patch stats do, including a last
+// line without a newline.
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	n := strings.Count(s, "\n")
+	if s[len(s)-1] != '\n' {
+		n++
+	}
+	return n
+}
+
+// crossCheckFileStats compares go-git stats for a commit against git numstat
+// and logs any discrepancy. It only runs with the go-git source, which stays
+// authoritative; the git source reads numstat in the first place.
+func crossCheckFileStats(repoPath, hash string, goGit []fileStat) {
+	fromGit, err := gitFileStats(repoPath, hash)
+	if err != nil {
+		slog.Warn("stats cross-check failed", "hash", hash, "err", err)
+		return
+	}
+	a, b := sumFileStats(goGit), sumFileStats(fromGit)
+	if a.files != b.files || a.additions != b.additions || a.deletions != b.deletions {
+		slog.Warn("stats sources disagree", "hash", hash, "using", statsSourceGoGit,
+			"goGitFiles", a.files, "goGitAdditions", a.additions, "goGitDeletions", a.deletions,
+			"gitFiles", b.files, "gitAdditions", b.additions, "gitDeletions", b.deletions)
+	}
+}
+
+func sumFileStats(stats []fileStat) commitStats {
+	var total commitStats
+	for _, s := range stats {
+		total.files++
+		total.additions += s.Additions
+		total.deletions += s.Deletions
+	}
+	total.churn = total.additions + total.deletions
+	return total
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// treePair is a commit's tree and its first parent's, empty for the root.
+type treePair struct {
+	message  string
+	from, to *object.Tree
+}
+
+func fixtureTreePairs(tb testing.TB, commits []fixtureCommit) []treePair {
+	tb.Helper()
+	r, err := git.PlainOpen(newFixtureRepo(tb, commits))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	iter, err := r.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var pairs []treePair
+	err = iter.ForEach(func(c *object.Commit) error {
+		to, err := c.Tree()
+		if err != nil {
+			return err
+		}
+		from := &object.Tree{}
+		if c.NumParents() > 0 {
+			parent, err := c.Parent(0)
+			if err != nil {
+				return err
+			}
+			if from, err = parent.Tree(); err != nil {
+				return err
+			}
+		}
+		pairs = append(pairs, treePair{message: strings.TrimSpace(c.Message), from: from, to: to})
+		return nil
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return pairs
+}
+
+// TestTreeFileStatsMatchPatchStats checks the chunk-free stats against the
+// go-git patch they replaced, over a root commit, a rename with an edit,
+// binary files and an empty file.
+func TestTreeFileStatsMatchPatchStats(t *testing.T) {
+	for _, p := range fixtureTreePairs(t, fixtureHistory) {
+		t.Run(p.message, func(t *testing.T) {
+			got, _, err := treeFileStats(p.from, p.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			patch, err := p.from.Patch(p.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want []fileStat
+			for _, s := range patch.Stats() {
+				want = append(want, fileStat{Name: s.Name, Additions: s.Addition, Deletions: s.Deletion})
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("treeFileStats = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkTreeFileStats(b *testing.B) {
+	pairs := fixtureTreePairs(b, fixtureHistory)
+	b.Run("tree stats", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for _, p := range pairs {
+				if _, _, err := treeFileStats(p.from, p.to); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("patch stats", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for _, p := range pairs {
+				patch, err := p.from.Patch(p.to)
+				if err != nil {
+					b.Fatal(err)
+				}
+				patch.Stats()
+			}
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package main
+
+import "sort"
+
+// syncStatYears keeps availableStatYears in step with the commits up to the
+// current one. Only the commits the index moved past since the last call are
+// visited, and the selected year keeps its place even as new years are
+// inserted ahead of it during loading.
+func (m *Model) syncStatYears() {
+	if m.statYearCounts == nil {
+		m.statYearCounts = make(map[int]int)
+		m.statYearsThrough = -1
+		m.availableStatYears = []int{0} // 0 for All-Time
+	}
+
+	changed := false
+	for m.statYearsThrough < m.currentCommitIndex && m.statYearsThrough+1 < len(m.commits) {
+		m.statYearsThrough++
+		changed = m.countStatYear(m.commits[m.statYearsThrough], 1) || changed
+	}
+	for m.statYearsThrough > m.currentCommitIndex {
+		changed = m.countStatYear(m.commits[m.statYearsThrough], -1) || changed
+		m.statYearsThrough--
+	}
+	if !changed {
+		return
+	}
+
+	years := make([]int, 0, len(m.statYearCounts))
+	for year := range m.statYearCounts {
+		years = append(years, year)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(years)))
+	m.availableStatYears = append([]int{0}, years...)
+
+	m.currentStatYearIndex = 0
+	for i, year := range m.availableStatYears {
+		if year == m.displayedStatsYear {
+			m.currentStatYearIndex = i
+			return
+		}
+	}
+	// The selected year is no longer reachable; fall back to All-Time.
+	m.displayedStatsYear = 0
+}
+
+// countStatYear adds delta to the count for c's year and reports whether the
+// year appeared in or dropped out of the list.
+func (m *Model) countStatYear(c *commitInfo, delta int) bool {
+	if hasUnknownDate(c) {
+		return false
+	}
+	year := c.Date.Year()
+	m.statYearCounts[year] += delta
+	if m.statYearCounts[year] == 0 {
+		delete(m.statYearCounts, year)
+		return true
+	}
+	return delta > 0 && m.statYearCounts[year] == 1
+}
+
+// resetStatYears drops the year counts after m.commits is replaced.
+func (m *Model) resetStatYears() {
+	m.statYearCounts = nil
+}
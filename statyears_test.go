@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestSelectedYearSurvivesLoading(t *testing.T) {
+	m := InitialModel(fixtureConfig(t, t.TempDir()))
+	m.autoProgress = false
+	load := func(years ...int) {
+		for _, year := range years {
+			m.appendCommit(&commitInfo{Hash: "h", Author: "Alice", Date: time.Date(year, time.June, 1, 12, 0, 0, 0, time.UTC)})
+			m.currentCommitIndex = len(m.commits) - 1
+			m.syncStatYears()
+		}
+	}
+	load(2023, 2024)
+
+	// Years are listed newest first after All-Time: down twice picks 2023.
+	for range 2 {
+		m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	}
+	if m.displayedStatsYear != 2023 {
+		t.Fatalf("selected year = %d, want 2023", m.displayedStatsYear)
+	}
+
+	load(2025, 2022, 2023, 2026)
+	if m.displayedStatsYear != 2023 {
+		t.Errorf("selected year moved to %d while loading", m.displayedStatsYear)
+	}
+	if got := m.availableStatYears[m.currentStatYearIndex]; got != 2023 {
+		t.Errorf("year index points at %d, want 2023 in %v", got, m.availableStatYears)
+	}
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m.displayedStatsYear != 2022 {
+		t.Errorf("next year after 2023 = %d, want 2022", m.displayedStatsYear)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SubjectRewrite replaces matches of Pattern in commit subjects on the
+// timeline with Replace, which may refer to groups as $1.
+type SubjectRewrite struct {
+	Pattern string `yaml:"pattern"`
+	Replace string `yaml:"replace"`
+}
+
+// subjectPresets are the ready-made rewrites selectable with -subject-preset.
+var subjectPresets = map[string]SubjectRewrite{
+	// Leading :shortcode: or emoji, as gitmoji puts them.
+	"strip-gitmoji": {Pattern: `^(:[a-z0-9_+-]+:|[\x{1F000}-\x{1FAFF}\x{2600}-\x{27BF}]\x{FE0F}?)\s*`},
+	// Leading [JIRA-123] or [WIP] style tags.
+	"strip-brackets": {Pattern: `^(\[[^\]]*\]\s*)+`},
+}
+
+type subjectRewriter struct {
+	re      *regexp.Regexp
+	replace string
+}
+
+// compileSubjectRewrites compiles the presets followed by the custom rewrites,
+// in the order they are applied.
+func compileSubjectRewrites(presets []string, rewrites []SubjectRewrite) ([]subjectRewriter, error) {
+	all := make([]SubjectRewrite, 0, len(presets)+len(rewrites))
+	for _, name := range presets {
+		preset, ok := subjectPresets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown subject preset: %s. supported presets are: strip-brackets, strip-gitmoji", name)
+		}
+		all = append(all, preset)
+	}
+	all = append(all, rewrites...)
+
+	compiled := make([]subjectRewriter, 0, len(all))
+	for _, r := range all {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject pattern %q: %v", r.Pattern, err)
+		}
+		compiled = append(compiled, subjectRewriter{re: re, replace: r.Replace})
+	}
+	return compiled, nil
+}
+
+// displaySubject is the first line of msg as the timeline shows it, with the
+// subject rewrites applied. A subject rewritten to nothing is left as it was.
+func (m *Model) displaySubject(msg string) string {
+	subject, _, _ := strings.Cut(msg, "\n")
+	if len(m.subjectRewrites) == 0 {
+		return subject
+	}
+	rewritten := subject
+	for _, r := range m.subjectRewrites {
+		rewritten = r.re.ReplaceAllString(rewritten, r.replace)
+	}
+	if rewritten = strings.TrimSpace(rewritten); rewritten == "" {
+		return subject
+	}
+	return rewritten
+}
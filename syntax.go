@@ -0,0 +1,164 @@
+package main
+
+import (
+	"path"
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+)
+
+// syntaxLanguage is enough of a language to color keywords, strings, numbers
+// and line comments in the diff view. Block comments are left alone, as a
+// diff window rarely shows where one starts.
+type syntaxLanguage struct {
+	keywords     map[string]bool
+	lineComments []string
+	quotes       string // Characters that open and close string literals
+}
+
+func newSyntaxLanguage(keywords, quotes string, lineComments ...string) *syntaxLanguage {
+	l := &syntaxLanguage{keywords: make(map[string]bool), lineComments: lineComments, quotes: quotes}
+	for _, k := range strings.Fields(keywords) {
+		l.keywords[k] = true
+	}
+	return l
+}
+
+var (
+	cFamilyKeywords = "if else for while do switch case default break continue return goto struct union enum typedef static const void int char long short unsigned signed float double sizeof true false null class public private protected new delete this try catch throw namespace using template virtual import package extends implements interface final abstract"
+
+	syntaxGo     = newSyntaxLanguage("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var nil true false iota", "\"'`", "//")
+	syntaxPython = newSyntaxLanguage("and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield None True False self", "\"'", "#")
+	syntaxJS     = newSyntaxLanguage("async await break case catch class const continue default delete do else export extends finally for from function if import in instanceof let new of return static super switch this throw try typeof var void while yield null undefined true false interface type enum implements", "\"'`", "//")
+	syntaxC      = newSyntaxLanguage(cFamilyKeywords, "\"'", "//")
+	syntaxRust   = newSyntaxLanguage("as async await break const continue crate dyn else enum extern false fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where while", "\"", "//")
+	syntaxRuby   = newSyntaxLanguage("begin break case class def do else elsif end ensure false for if in module next nil not or redo rescue retry return self super then true undef unless until when while yield require", "\"'", "#")
+	syntaxShell  = newSyntaxLanguage("if then else elif fi case esac for while until do done in function return local export set", "\"'", "#")
+	syntaxYAML   = newSyntaxLanguage("true false null yes no", "\"", "#") // Apostrophes in plain values outnumber quoted strings
+)
+
+// syntaxByExt maps file extensions to their language.
+var syntaxByExt = map[string]*syntaxLanguage{
+	".go": syntaxGo,
+	".py": syntaxPython,
+	".js": syntaxJS, ".jsx": syntaxJS, ".ts": syntaxJS, ".tsx": syntaxJS, ".mjs": syntaxJS,
+	".c": syntaxC, ".h": syntaxC, ".cc": syntaxC, ".cpp": syntaxC, ".hpp": syntaxC, ".java": syntaxC, ".cs": syntaxC, ".kt": syntaxC, ".swift": syntaxC,
+	".rs": syntaxRust,
+	".rb": syntaxRuby,
+	".sh": syntaxShell, ".bash": syntaxShell, ".zsh": syntaxShell,
+	".yml": syntaxYAML, ".yaml": syntaxYAML, ".toml": syntaxYAML,
+}
+
+// Token colors; the rest of a line keeps its addition or deletion color.
+var (
+	syntaxKeywordStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("141"))
+	syntaxStringStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("180"))
+	syntaxNumberStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("173"))
+	syntaxCommentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+)
+
+// diffFileLanguage returns the language of the file a diff header line
+// names, and whether the line was such a header at all.
+func diffFileLanguage(line string) (*syntaxLanguage, bool) {
+	var name string
+	switch {
+	case strings.HasPrefix(line, "diff --git "):
+		fields := strings.Fields(line)
+		name = fields[len(fields)-1]
+	case strings.HasPrefix(line, "+++ "):
+		name = strings.TrimPrefix(line, "+++ ")
+	default:
+		return nil, false
+	}
+	return syntaxByExt[strings.ToLower(path.Ext(name))], true
+}
+
+// diffLanguageAt finds the language of the file line i of the diff belongs to.
+func (m *Model) diffLanguageAt(i int) *syntaxLanguage {
+	for ; i >= 0 && i < len(m.currentDiffLines); i-- {
+		if lang, ok := diffFileLanguage(m.currentDiffLines[i]); ok {
+			return lang
+		}
+	}
+	return nil
+}
+
+// isDiffCodeLine reports whether line is file content rather than a header.
+func isDiffCodeLine(line string) bool {
+	if line == "" || strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- ") {
+		return false
+	}
+	return line[0] == '+' || line[0] == '-' || line[0] == ' '
+}
+
+// highlightCode renders a diff content line with lang's tokens colored and
+// everything else, including the +/- marker, in base.
+func highlightCode(line string, lang *syntaxLanguage, base lipgloss.Style) string {
+	var b strings.Builder
+	b.WriteString(base.Render(line[:1]))
+	code := line[1:]
+	plainStart := 0
+	flush := func(end int) {
+		if end > plainStart {
+			b.WriteString(base.Render(code[plainStart:end]))
+		}
+	}
+	for i := 0; i < len(code); {
+		c := code[i]
+		if comment := lang.commentAt(code[i:]); comment {
+			flush(i)
+			b.WriteString(syntaxCommentStyle.Render(code[i:]))
+			return b.String()
+		}
+		switch {
+		case strings.IndexByte(lang.quotes, c) >= 0:
+			end := i + 1
+			for end < len(code) && code[end] != c {
+				if code[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(code))
+			flush(i)
+			b.WriteString(syntaxStringStyle.Render(code[i:end]))
+			i, plainStart = end, end
+		case isWordByte(c):
+			end := i
+			for end < len(code) && isWordByte(code[end]) {
+				end++
+			}
+			word := code[i:end]
+			var style *lipgloss.Style
+			if lang.keywords[word] {
+				style = &syntaxKeywordStyle
+			} else if unicode.IsDigit(rune(word[0])) {
+				style = &syntaxNumberStyle
+			}
+			if style != nil {
+				flush(i)
+				b.WriteString(style.Render(word))
+				plainStart = end
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	flush(len(code))
+	return b.String()
+}
+
+func (l *syntaxLanguage) commentAt(s string) bool {
+	for _, prefix := range l.lineComments {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestHighlightStrings(t *testing.T) {
+	stringColor, _, _ := strings.Cut(syntaxStringStyle.Render("x"), "x")
+	tests := []struct {
+		name       string
+		line       string
+		lang       *syntaxLanguage
+		wantString bool
+	}{
+		{"rust lifetime", "+fn first<'a>(s: &'a str) -> &'a str {", syntaxRust, false},
+		{"yaml apostrophe", "+description: don't stop here", syntaxYAML, false},
+		{"yaml double quotes", `+name: "quoted"`, syntaxYAML, true},
+		{"go rune", "+r := 'x'", syntaxGo, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := highlightCode(tt.line, tt.lang, lipgloss.NewStyle())
+			if strings.Contains(got, stringColor) != tt.wantString {
+				t.Errorf("highlightCode(%q) = %q, want a string literal: %v", tt.line, got, tt.wantString)
+			}
+		})
+	}
+}
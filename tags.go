@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// tagsLoadedMsg carries the tags for the timeline once loadTagsCmd is done.
+type tagsLoadedMsg struct {
+	tags map[string][]string // commit hash -> tag names
+}
+
+// loadTagsCmd reads the tags matching -tags-glob off the UI thread, so a repo
+// with tens of thousands of them doesn't hold up startup. Only the newest
+// -max-tags are kept.
+func (m *Model) loadTagsCmd() tea.Cmd {
+	if m.config.TagsGlob == "" || m.config.Demo {
+		return nil
+	}
+	glob, limit, repoPath := m.config.TagsGlob, m.config.MaxTags, m.config.RepoPath
+	return func() tea.Msg {
+		start := time.Now()
+		r, err := git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+		if err != nil {
+			slog.Warn("failed to open repository for tags", "path", repoPath, "err", err)
+			return nil
+		}
+		tags, total, err := loadTags(r, glob, limit)
+		if err != nil {
+			slog.Warn("failed to load tags", "err", err)
+			return nil
+		}
+		slog.Info("loaded tags", "glob", glob, "tags", total, "elapsed", time.Since(start))
+		return tagsLoadedMsg{tags: tags}
+	}
+}
+
+// loadTags maps commits to the names of the tags matching glob that point at
+// them, keeping the limit newest tags (all of them when limit is 0). It also
+// returns how many tags matched.
+func loadTags(r *git.Repository, glob string, limit int) (map[string][]string, int, error) {
+	type tag struct {
+		name, commit string
+		when         time.Time
+	}
+	refs, err := r.Tags()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tags: %v", err)
+	}
+	var matched []tag
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if ok, _ := path.Match(glob, name); !ok {
+			return nil
+		}
+		// Annotated tags point at a tag object, lightweight ones at the commit.
+		if t, err := r.TagObject(ref.Hash()); err == nil {
+			if t.TargetType == plumbing.CommitObject {
+				matched = append(matched, tag{name: name, commit: t.Target.String(), when: t.Tagger.When})
+			}
+			return nil
+		}
+		if c, err := r.CommitObject(ref.Hash()); err == nil {
+			matched = append(matched, tag{name: name, commit: c.Hash.String(), when: c.Committer.When})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read tags: %v", err)
+	}
+
+	total := len(matched)
+	sort.Slice(matched, func(i, j int) bool { return matched[i].when.After(matched[j].when) })
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	tags := make(map[string][]string, len(matched))
+	for _, t := range matched {
+		tags[t.commit] = append(tags[t.commit], t.name)
+	}
+	for _, names := range tags {
+		sort.Strings(names)
+	}
+	return tags, total, nil
+}
+
+// tagLabel is the timeline marker for a commit's tags: the first name, cut
+// to maxName runes, and a count of any others.
+func (m *Model) tagLabel(hash string, maxName int) string {
+	names := m.tags[hash]
+	if len(names) == 0 {
+		return ""
+	}
+	name := []rune(names[0])
+	if len(name) > maxName {
+		name = append(name[:max(maxName-1, 0)], '…')
+	}
+	if len(names) == 1 {
+		return "(" + string(name) + ") "
+	}
+	return fmt.Sprintf("(%s +%d) ", string(name), len(names)-1)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"image/color"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// Panel frame, shared by every dashboard panel.
+var (
+	panelBorder      = lipgloss.RoundedBorder()
+	panelBorderColor = lipgloss.Color("239")
+	panelTitleColor  = lipgloss.Color("147")
+)
+
+// applyHighContrast swaps the muted palette for bright colors and thick borders.
+// Every foreground below clears 7:1 (WCAG AAA) against a black background:
+//
+//	15 white 21:1, 229 light yellow 20.2:1, 51 cyan 16.7:1, 46 green 15.3:1,
+//	220 gold 15:1, 117 sky 13.2:1, 250 light gray 11.1:1, 213 pink 10.2:1,
+//	210 salmon 9.1:1
+func applyHighContrast() {
+	panelBorder = lipgloss.ThickBorder()
+	panelBorderColor = lipgloss.Color("15")
+	panelTitleColor = lipgloss.Color("229")
+
+	panelStyle = panelStyle.Border(panelBorder).BorderForeground(panelBorderColor)
+	headerStyle = headerStyle.Foreground(lipgloss.Color("229"))
+	statsLabelStyle = statsLabelStyle.Foreground(lipgloss.Color("15"))
+	statsValueStyle = statsValueStyle.Foreground(lipgloss.Color("51"))
+
+	barStyle = barStyle.Foreground(lipgloss.Color("51"))
+	barLabelStyle = barLabelStyle.Foreground(lipgloss.Color("220"))
+	barValueStyle = barValueStyle.Foreground(lipgloss.Color("15"))
+	barMessageStyle = barMessageStyle.Foreground(lipgloss.Color("15"))
+
+	additionStyle = additionStyle.Foreground(lipgloss.Color("46"))
+	deletionStyle = deletionStyle.Foreground(lipgloss.Color("210"))
+	graphAxisStyle = graphAxisStyle.Foreground(lipgloss.Color("250"))
+	graphHighlight = graphHighlight.Foreground(lipgloss.Color("15"))
+	warningStyle = warningStyle.Foreground(lipgloss.Color("220"))
+
+	signedGlyphStyle = signedGlyphStyle.Foreground(lipgloss.Color("46"))
+	unsignedGlyphStyle = unsignedGlyphStyle.Foreground(lipgloss.Color("250"))
+	alertStyle = alertStyle.Foreground(lipgloss.Color("213"))
+	dagStyle = dagStyle.Foreground(lipgloss.Color("117"))
+	mergeGlyphStyle = mergeGlyphStyle.Foreground(lipgloss.Color("177"))
+	annotationStyle = annotationStyle.Foreground(lipgloss.Color("229"))
+	changedAdditionStyle = changedAdditionStyle.Foreground(lipgloss.Color("15")).Background(lipgloss.Color("28"))
+	changedDeletionStyle = changedDeletionStyle.Foreground(lipgloss.Color("15")).Background(lipgloss.Color("124"))
+	tagStyle = tagStyle.Foreground(lipgloss.Color("220"))
+	searchMatchStyle = searchMatchStyle.Background(lipgloss.Color("229"))
+	syntaxKeywordStyle = syntaxKeywordStyle.Foreground(lipgloss.Color("207"))
+	syntaxCommentStyle = syntaxCommentStyle.Foreground(lipgloss.Color("250"))
+
+	dirChartColors = []string{"51", "220", "213", "46", "210", "117", "15"}
+}
+
+// Braille gradients for terminals without truecolor. Downsampling the hex
+// gradients maps neighbouring shades onto the same few colors and muddies
+// them, so these steps are picked from the target palette directly.
+var (
+	ansi256AdditionGradient = []color.Color{
+		lipgloss.Color("194"), lipgloss.Color("193"), lipgloss.Color("157"), lipgloss.Color("156"), lipgloss.Color("120"),
+		lipgloss.Color("119"), lipgloss.Color("84"), lipgloss.Color("83"), lipgloss.Color("47"), lipgloss.Color("46"),
+	}
+	ansi256DeletionGradient = []color.Color{
+		lipgloss.Color("196"), lipgloss.Color("197"), lipgloss.Color("203"), lipgloss.Color("204"), lipgloss.Color("210"),
+		lipgloss.Color("211"), lipgloss.Color("217"), lipgloss.Color("218"), lipgloss.Color("224"), lipgloss.Color("225"),
+	}
+	// With 16 colors only the bright and normal variants remain.
+	ansiAdditionGradient = []color.Color{lipgloss.Color("10"), lipgloss.Color("2")}
+	ansiDeletionGradient = []color.Color{lipgloss.Color("1"), lipgloss.Color("9")}
+)
+
+// applyColorProfile switches the braille gradients to the palette the
+// terminal can show. Profiles without color need nothing; lipgloss drops the
+// colors when rendering.
+func applyColorProfile(p colorprofile.Profile) {
+	switch p {
+	case colorprofile.ANSI256:
+		additionGradient, deletionGradient = ansi256AdditionGradient, ansi256DeletionGradient
+	case colorprofile.ANSI:
+		additionGradient, deletionGradient = ansiAdditionGradient, ansiDeletionGradient
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import "fmt"
+
+// Panel ids for the titles config section.
+const (
+	panelStats      = "stats"
+	panelChanges    = "changes"
+	panelDirs       = "dirs"
+	panelTimeline   = "timeline"
+	panelDevelopers = "developers"
+	panelLifetimes  = "lifetimes"
+)
+
+var defaultPanelTitles = map[string]string{
+	panelStats:      "Commit & Project Stats",
+	panelChanges:    "Commit Changes",
+	panelDirs:       "Churn by Directory",
+	panelTimeline:   "Commit Timeline",
+	panelDevelopers: "Developer Stats",
+	panelLifetimes:  "Longest-Lived Files",
+}
+
+// panelTitle returns the configured title for a panel, or its default.
+func (m *Model) panelTitle(id string) string {
+	if title := m.config.Titles[id]; title != "" {
+		return title
+	}
+	return tr(defaultPanelTitles[id])
+}
+
+func validatePanelTitles(titles map[string]string) error {
+	for id := range titles {
+		if _, ok := defaultPanelTitles[id]; !ok {
+			return fmt.Errorf("unknown panel: %s", id)
+		}
+	}
+	return nil
+}
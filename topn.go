@@ -0,0 +1,49 @@
+package main
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// contributorHeap is a min-heap under contributorLess: the root is the entry
+// that would be ranked last, so it is the one evicted when a better one arrives.
+type contributorHeap struct {
+	mode  int
+	stats []authorStat
+}
+
+func (h *contributorHeap) Len() int { return len(h.stats) }
+func (h *contributorHeap) Less(i, j int) bool {
+	return contributorLess(h.mode, h.stats[j], h.stats[i])
+}
+func (h *contributorHeap) Swap(i, j int) { h.stats[i], h.stats[j] = h.stats[j], h.stats[i] }
+func (h *contributorHeap) Push(x any)    { h.stats = append(h.stats, x.(authorStat)) }
+func (h *contributorHeap) Pop() any {
+	last := h.stats[len(h.stats)-1]
+	h.stats = h.stats[:len(h.stats)-1]
+	return last
+}
+
+// topContributors returns the n highest-ranked authors in display order. It
+// keeps a bounded heap instead of sorting every author, which matters on repos
+// with tens of thousands of contributors.
+func topContributors(authors map[string]*authorStat, mode, n int) []authorStat {
+	if n <= 0 {
+		return nil
+	}
+	h := &contributorHeap{mode: mode, stats: make([]authorStat, 0, n+1)}
+	for _, a := range authors {
+		if h.Len() < n {
+			heap.Push(h, *a)
+			continue
+		}
+		if contributorLess(mode, *a, h.stats[0]) {
+			h.stats[0] = *a
+			heap.Fix(h, 0)
+		}
+	}
+	sort.Slice(h.stats, func(i, j int) bool {
+		return contributorLess(mode, h.stats[i], h.stats[j])
+	})
+	return h.stats
+}
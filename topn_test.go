@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTopContributorsMatchesFullSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	authors := make(map[string]*authorStat)
+	for i := range 500 {
+		key := fmt.Sprintf("author%03d", i)
+		// Small ranges so ties fall through to the churn and name tiebreaks.
+		authors[key] = &authorStat{
+			key:       key,
+			name:      key,
+			churn:     rng.Intn(50),
+			commits:   rng.Intn(10),
+			additions: rng.Intn(30),
+			deletions: rng.Intn(30),
+			last:      time.Date(2024, time.January, 1+rng.Intn(20), 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	for mode := range numContributorSorts {
+		sorted := make([]authorStat, 0, len(authors))
+		for _, a := range authors {
+			sorted = append(sorted, *a)
+		}
+		sort.Slice(sorted, func(i, j int) bool { return contributorLess(mode, sorted[i], sorted[j]) })
+
+		for _, n := range []int{1, 5, 37, len(authors), len(authors) + 10} {
+			got := topContributors(authors, mode, n)
+			want := sorted[:min(n, len(sorted))]
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s, n=%d: heap and sort disagree", contributorSortNames[mode], n)
+			}
+		}
+	}
+	if got := topContributors(authors, sortByChurn, 0); got != nil {
+		t.Errorf("n=0 returned %d authors", len(got))
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+// tourStop reports whether playback should pause on c: in tour mode every
+// annotated commit is a stop.
+func (m *Model) tourStop(c *commitInfo) bool {
+	return m.config.Tour && m.annotation(c.Hash) != ""
+}
+
+// resumeTour continues playback after a tour stop.
+func (m *Model) resumeTour() {
+	m.tourPaused = false
+	m.autoProgress = true
+}
+
+// jumpAnnotation moves to the next (dir > 0) or previous annotated commit among
+// those loaded, in timeline order.
+func (m *Model) jumpAnnotation(dir int) {
+	m.autoProgress = false
+	for i := m.currentCommitIndex + dir; i >= 0 && i < len(m.commits); i += dir {
+		if m.annotation(m.commits[i].Hash) != "" {
+			m.currentCommitIndex = i
+			return
+		}
+	}
+}
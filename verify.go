@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+type statMismatch struct {
+	hash     string
+	computed commitStats
+	expected commitStats
+}
+
+// runVerify compares the fetcher's per-commit stats with git's own numstat output.
+// It returns false when any commit disagrees.
+func runVerify(config Config, w io.Writer) (bool, error) {
+	commits := collectCommits(config)
+	if len(commits) == 0 {
+		return false, fmt.Errorf("no commits found in %s", config.RepoPath)
+	}
+
+	hashes := make([]string, 0, len(commits))
+	for _, c := range commits {
+		hashes = append(hashes, c.Hash)
+	}
+	expectedByHash, err := runGitNumstat(config.RepoPath, hashes, false, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var computedTotal, expectedTotal commitStats
+	var mismatches []statMismatch
+	for _, c := range commits {
+		computed := commitStats{files: c.Files, additions: c.Additions, deletions: c.Deletions, churn: c.Churn}
+		expected := expectedByHash[c.Hash]
+		computedTotal = addStats(computedTotal, computed)
+		expectedTotal = addStats(expectedTotal, expected)
+		if computed.files != expected.files || computed.additions != expected.additions || computed.deletions != expected.deletions {
+			mismatches = append(mismatches, statMismatch{hash: c.Hash, computed: computed, expected: expected})
+		}
+	}
+
+	for _, mm := range mismatches {
+		fmt.Fprintf(w, "%s  files %d/%d  additions %+d  deletions %+d\n",
+			mm.hash[:7],
+			mm.computed.files, mm.expected.files,
+			mm.computed.additions-mm.expected.additions,
+			mm.computed.deletions-mm.expected.deletions)
+	}
+
+	fmt.Fprintf(w, "Totals (computed / git): files %d / %d, additions %d / %d, deletions %d / %d\n",
+		computedTotal.files, expectedTotal.files,
+		computedTotal.additions, expectedTotal.additions,
+		computedTotal.deletions, expectedTotal.deletions)
+	if len(mismatches) > 0 {
+		fmt.Fprintf(w, "FAIL: %d of %d commits differ\n", len(mismatches), len(commits))
+		return false, nil
+	}
+	fmt.Fprintf(w, "PASS: %d commits match\n", len(commits))
+	return true, nil
+}
+
+func addStats(a, b commitStats) commitStats {
+	return commitStats{
+		files:     a.files + b.files,
+		additions: a.additions + b.additions,
+		deletions: a.deletions + b.deletions,
+		churn:     a.churn + b.churn,
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// textHistory only has changes go-git and git numstat count alike, unlike
+// the binaries and renames -verify is there to flag.
+var textHistory = []fixtureCommit{
+	{
+		author:  "Alice",
+		date:    "2024-01-01T10:00:00Z",
+		message: "Initial import",
+		write: map[string]string{
+			"README.md": "# Fixture\n\nA small repository.\n",
+			"main.go":   "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+		},
+	},
+	{
+		author:  "Bob",
+		date:    "2024-01-02T11:30:00Z",
+		message: "Greet the world",
+		write: map[string]string{
+			"main.go": "package main\n\nfunc main() {\n\tprintln(\"hello\")\n\tprintln(\"world\")\n}\n",
+			"util.go": "package main\n",
+		},
+	},
+	{
+		author:  "Alice",
+		date:    "2024-01-05T09:15:00Z",
+		message: "Drop the readme",
+		remove:  []string{"README.md"},
+	},
+}
+
+func TestVerifyPassesOnFreshRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []fixtureCommit
+	}{
+		{"root commit only", textHistory[:1]},
+		{"history", textHistory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := fixtureConfig(t, newFixtureRepo(t, tt.commits))
+			var out strings.Builder
+			ok, err := runVerify(cfg, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatalf("verify reported mismatches:\n%s", out.String())
+			}
+		})
+	}
+}
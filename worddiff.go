@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// maxWordDiffTokens bounds the token LCS, which is quadratic, for very long
+// lines such as minified code.
+const maxWordDiffTokens = 400
+
+// wordDiffPairs returns the pairs of replacementPairs for the diff on screen,
+// or nil when word diffs are off.
+func (m *Model) wordDiffPairs() map[int]int {
+	if !m.config.WordDiff {
+		return nil
+	}
+	return m.diffPairs
+}
+
+// replacementPairs matches removed lines with the added lines that replace
+// them, the same way the side-by-side view lines them up. It maps both ways.
+func replacementPairs(lines []string) map[int]int {
+	pairs := make(map[int]int)
+	for _, row := range splitRows(lines) {
+		if !row.whole && row.old >= 0 && row.new >= 0 && row.old != row.new {
+			pairs[row.old], pairs[row.new] = row.new, row.old
+		}
+	}
+	return pairs
+}
+
+// wordTokens splits s into words, runs of whitespace and single other
+// characters.
+func wordTokens(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		j := i + 1
+		switch {
+		case isWordByte(s[i]):
+			for j < len(s) && isWordByte(s[j]) {
+				j++
+			}
+		case s[i] == ' ':
+			for j < len(s) && s[j] == ' ' {
+				j++
+			}
+		default:
+			// Keep multi-byte runes whole.
+			for j < len(s) && s[j]&0xC0 == 0x80 {
+				j++
+			}
+		}
+		tokens = append(tokens, s[i:j])
+		i = j
+	}
+	return tokens
+}
+
+// lcsTable is the scratch table of changedSpans. It is kept on the model and
+// grown as needed, so the word diffs of a frame don't each allocate one.
+type lcsTable []int
+
+// changedSpans returns the byte ranges of line, past its +/- marker, that
+// are not in other according to a longest common subsequence of their
+// tokens. ok is false when the lines are too long to compare or share
+// nothing, as highlighting the whole line would say nothing new.
+func (t *lcsTable) changedSpans(line, other string) (spans [][2]int, ok bool) {
+	a, b := wordTokens(line[1:]), wordTokens(other[1:])
+	if len(a) > maxWordDiffTokens || len(b) > maxWordDiffTokens {
+		return nil, false
+	}
+	// lcs(i, j) is the LCS length of a[i:] and b[j:].
+	cols := len(b) + 1
+	if n := (len(a) + 1) * cols; cap(*t) < n {
+		*t = make(lcsTable, n)
+	} else {
+		*t = (*t)[:n]
+		clear(*t)
+	}
+	cells := *t
+	lcs := func(i, j int) int { return cells[i*cols+j] }
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				cells[i*cols+j] = lcs(i+1, j+1) + 1
+			} else {
+				cells[i*cols+j] = max(lcs(i+1, j), lcs(i, j+1))
+			}
+		}
+	}
+	if lcs(0, 0) == 0 {
+		return nil, false
+	}
+
+	pos := 1
+	for i, j := 0, 0; i < len(a); i++ {
+		for j < len(b) && a[i] != b[j] && lcs(i, j+1) > lcs(i+1, j) {
+			j++
+		}
+		if j < len(b) && a[i] == b[j] {
+			j++
+		} else if n := len(spans); n > 0 && spans[n-1][1] == pos {
+			spans[n-1][1] += len(a[i])
+		} else {
+			spans = append(spans, [2]int{pos, pos + len(a[i])})
+		}
+		pos += len(a[i])
+	}
+	return spans, true
+}
+
+// renderWordDiff renders line in base with the changed spans in changed.
+// Spans past the end of a clipped line are cut short.
+func renderWordDiff(line string, spans [][2]int, base, changed lipgloss.Style) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		from, to := min(span[0], len(line)), min(span[1], len(line))
+		if from == to {
+			break
+		}
+		b.WriteString(base.Render(line[last:from]))
+		b.WriteString(changed.Render(line[from:to]))
+		last = to
+	}
+	b.WriteString(base.Render(line[last:]))
+	return b.String()
+}
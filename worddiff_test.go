@@ -0,0 +1,31 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedSpansReuseTable(t *testing.T) {
+	tests := []struct {
+		line, other string
+		want        [][2]int
+		wantOK      bool
+	}{
+		{"+\treturn a + b", "-\treturn a - b", [][2]int{{11, 12}}, true},
+		{"+x := compute(first, second, third)", "-x := compute(first, third)", [][2]int{{21, 29}}, true},
+		{"+abc", "-xyz", nil, false},
+		{"+same", "-same", nil, true},
+	}
+	var shared lcsTable
+	for _, tt := range tests {
+		var fresh lcsTable
+		want, wantOK := fresh.changedSpans(tt.line, tt.other)
+		got, ok := shared.changedSpans(tt.line, tt.other)
+		if !reflect.DeepEqual(got, want) || ok != wantOK {
+			t.Errorf("%q vs %q: reused table gives %v, %v; a fresh one %v, %v", tt.line, tt.other, got, ok, want, wantOK)
+		}
+		if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOK {
+			t.Errorf("%q vs %q: spans %v, %v, want %v, %v", tt.line, tt.other, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
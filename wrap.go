@@ -0,0 +1,83 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// messageIndent sets the commit message apart from the diff below it, like
+// `git log`, and keeps "- " bullets from being colored as deletions.
+const messageIndent = "    "
+
+// minWrapWidth keeps wrapping sane on very narrow terminals.
+const minWrapWidth = 10
+
+var bulletPattern = regexp.MustCompile(`^([-*+]|\d+[.)])\s+`)
+
+// wrapMessage reflows a commit message body to width columns. Blank lines are
+// kept, each bullet starts a new line with its continuation lines aligned to
+// the bullet text, and indented lines (code, quoted output) are left as they are.
+func wrapMessage(msg string, width int) []string {
+	width = max(width, minWrapWidth)
+	var out []string
+	var para []string
+	prefix, hang := "", ""
+	flush := func() {
+		if len(para) > 0 {
+			out = append(out, wrapWords(strings.Join(para, " "), prefix, hang, width)...)
+		}
+		para, prefix, hang = nil, "", ""
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(msg, "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		switch {
+		case line == "":
+			flush()
+			out = append(out, "")
+		case line[0] == ' ' || line[0] == '\t':
+			flush()
+			out = append(out, line)
+		case bulletPattern.MatchString(line):
+			flush()
+			marker := bulletPattern.FindString(line)
+			prefix, hang = marker, strings.Repeat(" ", utf8.RuneCountInString(marker))
+			para = append(para, line[len(marker):])
+		default:
+			para = append(para, line)
+		}
+	}
+	flush()
+	return out
+}
+
+// wrapWords fills lines up to width, starting the first with prefix and the
+// rest with hang. Words longer than a line are split.
+func wrapWords(text, prefix, hang string, width int) []string {
+	var lines []string
+	line, lineLen := prefix, utf8.RuneCountInString(prefix)
+	empty := true
+	for _, word := range strings.Fields(text) {
+		wordLen := utf8.RuneCountInString(word)
+		if !empty && lineLen+1+wordLen > width {
+			lines = append(lines, line)
+			line, lineLen, empty = hang, utf8.RuneCountInString(hang), true
+		}
+		for lineLen+wordLen > width && width-lineLen > 0 {
+			r := []rune(word)
+			cut := width - lineLen
+			lines = append(lines, line+string(r[:cut]))
+			word, wordLen = string(r[cut:]), wordLen-cut
+			line, lineLen = hang, utf8.RuneCountInString(hang)
+		}
+		if !empty {
+			line += " "
+			lineLen++
+		}
+		line += word
+		lineLen += wordLen
+		empty = false
+	}
+	return append(lines, line)
+}